@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	tsstypes "CipherMachine/rpc/core/types"
+)
+
+// ExportShareCmd writes the share of a key of a running node to a backup
+// file on the node. The node must enable the unsafe rpc methods.
+var ExportShareCmd = &cobra.Command{
+	Use:   "export-share <key_id> <path>",
+	Short: "Write the encrypted share of a key to a new backup file at path on the node",
+	Long: `Write the encrypted share of a key to a new backup file at path on the
+node. The node must run with rpc.unsafe, the passphrase of the backup is read
+from the terminal or stdin and sent to the node in the clear.`,
+	Args: cobra.ExactArgs(2),
+	RunE: exportShare,
+}
+
+// ImportShareCmd restores a share from a backup file on a running node.
+var ImportShareCmd = &cobra.Command{
+	Use:   "import-share <path>",
+	Short: "Restore the share in the backup file at path on the node",
+	Long: `Restore the share in the backup file at path on the node. The node must
+run with rpc.unsafe, the passphrase of the backup is read from the terminal or
+stdin and sent to the node in the clear.`,
+	Args: cobra.ExactArgs(1),
+	RunE: importShare,
+}
+
+func init() {
+	addClientFlags(ExportShareCmd)
+	addClientFlags(ImportShareCmd)
+}
+
+func exportShare(cmd *cobra.Command, args []string) error {
+	passphrase, err := readPassphrase("Backup passphrase: ", true)
+	if err != nil {
+		return err
+	}
+	res := new(tsstypes.ResultShareBackup)
+	err = callRPC("tss_export_share", map[string]interface{}{
+		"key_id":     args[0],
+		"path":       args[1],
+		"passphrase": passphrase,
+	}, res)
+	if err != nil {
+		return err
+	}
+	printJSON(res)
+	return nil
+}
+
+func importShare(cmd *cobra.Command, args []string) error {
+	passphrase, err := readPassphrase("Backup passphrase: ", false)
+	if err != nil {
+		return err
+	}
+	res := new(tsstypes.ResultShareBackup)
+	err = callRPC("tss_import_share", map[string]interface{}{
+		"path":       args[0],
+		"passphrase": passphrase,
+	}, res)
+	if err != nil {
+		return err
+	}
+	printJSON(res)
+	return nil
+}
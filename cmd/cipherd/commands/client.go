@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	rpcclient "github.com/tendermint/tendermint/rpc/lib/client"
+	"golang.org/x/crypto/ssh/terminal"
+
+	tsstypes "CipherMachine/rpc/core/types"
+)
+
+const (
+	// noHomeAnnotation marks the commands that need no node home, like the
+	// ones that talk to a running node over rpc
+	noHomeAnnotation = "no-home"
+
+	flagNode    = "node"
+	flagTimeout = "timeout"
+	flagHex     = "hex"
+	flagHashAlg = "hash_alg"
+
+	// sessionPollInterval is how often a waiting command asks the node for
+	// the status of its session
+	sessionPollInterval = 500 * time.Millisecond
+)
+
+var noHomeAnnotations = map[string]string{noHomeAnnotation: ""}
+
+// addClientFlags adds the flags of the commands that talk to a node.
+func addClientFlags(cmd *cobra.Command) {
+	cmd.Annotations = noHomeAnnotations
+	cmd.Flags().String(flagNode, "tcp://localhost:26657", "RPC address of the node")
+}
+
+func callRPC(method string, params map[string]interface{}, result interface{}) error {
+	cl := rpcclient.NewJSONRPCClient(viper.GetString(flagNode))
+	_, err := cl.Call(method, params, result)
+	return err
+}
+
+// waitSession polls the node until the session of id ends or the timeout
+// flag expires, and returns an error if it failed.
+func waitSession(id string) (*tsstypes.ResultSession, error) {
+	deadline := time.Now().Add(viper.GetDuration(flagTimeout))
+	for {
+		s := new(tsstypes.ResultSession)
+		if err := callRPC("tss_session_status", map[string]interface{}{"session_id": id}, s); err != nil {
+			return nil, err
+		}
+		switch s.Status {
+		case tsstypes.SessionDone:
+			return s, nil
+		case tsstypes.SessionFailed:
+			printJSON(s)
+			return nil, fmt.Errorf("session %s failed: %s", id, s.Error)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("session %s still running, ask the node for it with tss_session_status", id)
+		}
+		time.Sleep(sessionPollInterval)
+	}
+}
+
+func printJSON(v interface{}) {
+	bz, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(bz))
+}
+
+// messageArg returns the message argument, hex decoded if the hex flag is set.
+func messageArg(arg string) ([]byte, error) {
+	if !viper.GetBool(flagHex) {
+		return []byte(arg), nil
+	}
+	return hexArg(arg)
+}
+
+func hexArg(arg string) ([]byte, error) {
+	bz, err := hex.DecodeString(strings.TrimPrefix(arg, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid hex")
+	}
+	return bz, nil
+}
+
+// parsePath parses a BIP32 path of non-hardened indexes like m/0/7.
+func parsePath(s string) ([]uint32, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "m"), "/")
+	if s == "" {
+		return nil, nil
+	}
+	var path []uint32
+	for _, elem := range strings.Split(s, "/") {
+		i, err := strconv.ParseUint(elem, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path index %q, only non-hardened indexes can be derived", elem)
+		}
+		path = append(path, uint32(i))
+	}
+	return path, nil
+}
+
+// stdin is shared by the reads of the passphrases of a command, a reader per
+// read would drop the lines buffered after its own
+var stdin = bufio.NewReader(os.Stdin)
+
+// readPassphrase reads a passphrase from the terminal without echoing it, or
+// a line of stdin if it is not a terminal.
+func readPassphrase(prompt string, confirm bool) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		line, err := stdin.ReadString('\n')
+		if err != nil && line == "" {
+			return "", errors.Wrap(err, "could not read the passphrase from stdin")
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	bz, err := terminal.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat the passphrase: ")
+		again, err := terminal.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		if string(again) != string(bz) {
+			return "", errors.New("the passphrases do not match")
+		}
+	}
+	return string(bz), nil
+}
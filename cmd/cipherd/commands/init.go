@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/privval"
+
+	cfg "CipherMachine/config"
+	nm "CipherMachine/node"
+	"CipherMachine/p2p"
+	"CipherMachine/threshold"
+)
+
+// InitFilesCmd initialises a fresh node home.
+var InitFilesCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Initialize the node home with its config, node key, private validator and tss store",
+	Long: `Initialize the node home with its config, node key, private validator and
+tss store. The tss store is locked with the key file of [tss] key_file, which
+is generated if missing, or else with a passphrase read from ` + threshold.PassphraseEnv + `
+or the terminal. The node unlocks the store with the same secret at start.`,
+	RunE: initFiles,
+}
+
+func initFiles(cmd *cobra.Command, args []string) error {
+	return initFilesWithConfig(config)
+}
+
+func initFilesWithConfig(config *cfg.Config) error {
+	// private validator
+	privValKeyFile := config.PrivValidatorKeyFile()
+	privValStateFile := config.PrivValidatorStateFile()
+	if cmn.FileExists(privValKeyFile) {
+		logger.Info("Found private validator", "keyFile", privValKeyFile,
+			"stateFile", privValStateFile)
+	} else {
+		pv := privval.GenFilePV(privValKeyFile, privValStateFile)
+		pv.Save()
+		logger.Info("Generated private validator", "keyFile", privValKeyFile,
+			"stateFile", privValStateFile)
+	}
+
+	nodeKeyFile := config.NodeKeyFile()
+	if cmn.FileExists(nodeKeyFile) {
+		logger.Info("Found node key", "path", nodeKeyFile)
+	} else {
+		if _, err := p2p.LoadOrGenNodeKey(nodeKeyFile); err != nil {
+			return err
+		}
+		logger.Info("Generated node key", "path", nodeKeyFile)
+	}
+	return initTssStore(config)
+}
+
+// initTssStore gives the tss store its secret, the key file of the config or
+// else a passphrase read from the environment or the terminal.
+func initTssStore(config *cfg.Config) error {
+	db, err := nm.DefaultDBProvider(&nm.DBContext{ID: "store", Config: config})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	initialized, err := threshold.StoreInitialized(db)
+	if err != nil {
+		return err
+	}
+	if initialized {
+		logger.Info("Found tss store", "dir", config.DBDir())
+		return nil
+	}
+
+	var secret threshold.StoreSecret
+	if config.Tss != nil && config.Tss.KeyFile != "" {
+		secret.KeyFile = config.Tss.KeyFilePath()
+		if cmn.FileExists(secret.KeyFile) {
+			logger.Info("Found tss key file", "path", secret.KeyFile)
+		} else {
+			if err := threshold.GenerateKeyFile(secret.KeyFile); err != nil {
+				return err
+			}
+			logger.Info("Generated tss key file", "path", secret.KeyFile)
+		}
+	} else {
+		secret.Passphrase = os.Getenv(threshold.PassphraseEnv)
+		if secret.Passphrase == "" {
+			if secret.Passphrase, err = readPassphrase("Passphrase of the tss store: ", true); err != nil {
+				return err
+			}
+		}
+	}
+	if err := threshold.InitStore(db, secret); err != nil {
+		return err
+	}
+	logger.Info("Initialized tss store", "dir", config.DBDir())
+	return nil
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	tsstypes "CipherMachine/rpc/core/types"
+)
+
+// KeygenCmd generates a key with the persistent peers of a running node.
+var KeygenCmd = &cobra.Command{
+	Use:   "keygen <key_id>",
+	Short: "Generate a key with the node and its persistent peers",
+	Args:  cobra.ExactArgs(1),
+	RunE:  keygen,
+}
+
+func init() {
+	addClientFlags(KeygenCmd)
+	KeygenCmd.Flags().String("key_type", "ecdsa", "Key type, ecdsa, eddsa or schnorr")
+	KeygenCmd.Flags().String("curve", "", "Curve of the key, the default curve of the key type if empty")
+	KeygenCmd.Flags().Int("threshold", 0, "Threshold, threshold+1 parties sign. 0 is the threshold of the node's [tss] config")
+	KeygenCmd.Flags().Duration(flagTimeout, 10*time.Minute, "How long to wait for the keygen")
+}
+
+func keygen(cmd *cobra.Command, args []string) error {
+	s := new(tsstypes.ResultSession)
+	err := callRPC("tss_keygen", map[string]interface{}{
+		"key_id":    args[0],
+		"key_type":  viper.GetString("key_type"),
+		"curve":     viper.GetString("curve"),
+		"threshold": viper.GetInt("threshold"),
+	}, s)
+	if err != nil {
+		return err
+	}
+	s, err = waitSession(s.SessionID)
+	if err != nil {
+		return err
+	}
+	printJSON(s)
+	return nil
+}
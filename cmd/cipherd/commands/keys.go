@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	tsstypes "CipherMachine/rpc/core/types"
+	"CipherMachine/threshold"
+)
+
+// KeysCmd groups the commands on the keys of a running node.
+var KeysCmd = &cobra.Command{
+	Use:         "keys",
+	Short:       "Manage the keys of the node",
+	Annotations: noHomeAnnotations,
+}
+
+var listKeysCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the keys the node holds",
+	Args:  cobra.NoArgs,
+	RunE:  listKeys,
+}
+
+var retireKeyCmd = &cobra.Command{
+	Use:   "retire <key_id>",
+	Short: "Retire a key, the node refuses to sign with it from now on",
+	Args:  cobra.ExactArgs(1),
+	RunE:  retireKey,
+}
+
+var deleteKeyCmd = &cobra.Command{
+	Use:   "delete <key_id> <pub_key>",
+	Short: "Delete the share of a retired key for good",
+	Long: `Delete the share of a retired key for good. The public key of the key in
+hex, as keys list shows it, confirms the deletion. The node must run with
+rpc.unsafe.`,
+	Args: cobra.ExactArgs(2),
+	RunE: deleteKey,
+}
+
+func init() {
+	addClientFlags(listKeysCmd)
+	addClientFlags(retireKeyCmd)
+	addClientFlags(deleteKeyCmd)
+	KeysCmd.AddCommand(listKeysCmd, retireKeyCmd, deleteKeyCmd)
+}
+
+func listKeys(cmd *cobra.Command, args []string) error {
+	res := new(tsstypes.ResultKeys)
+	if err := callRPC("tss_keys", map[string]interface{}{}, res); err != nil {
+		return err
+	}
+	if res.Keys == nil {
+		res.Keys = []*threshold.KeyInfo{}
+	}
+	printJSON(res.Keys)
+	return nil
+}
+
+func retireKey(cmd *cobra.Command, args []string) error {
+	res := new(tsstypes.ResultKeyInfo)
+	if err := callRPC("tss_retire_key", map[string]interface{}{"key_id": args[0]}, res); err != nil {
+		return err
+	}
+	printJSON(res)
+	return nil
+}
+
+func deleteKey(cmd *cobra.Command, args []string) error {
+	res := new(tsstypes.ResultDeleteKey)
+	err := callRPC("tss_delete_key", map[string]interface{}{
+		"key_id":  args[0],
+		"confirm": strings.TrimPrefix(args[1], "0x"),
+	}, res)
+	if err != nil {
+		return err
+	}
+	printJSON(res)
+	return nil
+}
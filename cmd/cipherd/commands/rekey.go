@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	tsstypes "CipherMachine/rpc/core/types"
+)
+
+const (
+	flagOldKeyFile = "old_key_file"
+	flagNewKeyFile = "new_key_file"
+)
+
+// RekeyCmd replaces the secret of the tss store of a running node. The node
+// must enable the unsafe rpc methods.
+var RekeyCmd = &cobra.Command{
+	Use:   "rekey",
+	Short: "Replace the passphrase or key file of the tss store of the node",
+	Long: `Replace the passphrase or key file of the tss store of the node, the shares
+are not rewritten. The node must run with rpc.unsafe. The old and the new
+secret are each a key file on the node given by its flag, or else a passphrase
+read from the terminal or stdin and sent to the node in the clear. Set
+[tss] key_file or the passphrase of the node to the new secret before its next
+start.`,
+	Args: cobra.NoArgs,
+	RunE: rekey,
+}
+
+func init() {
+	addClientFlags(RekeyCmd)
+	RekeyCmd.Flags().String(flagOldKeyFile, "", "Key file on the node the tss store is locked with")
+	RekeyCmd.Flags().String(flagNewKeyFile, "", "Key file on the node to lock the tss store with")
+}
+
+func rekey(cmd *cobra.Command, args []string) error {
+	params := map[string]interface{}{
+		"old_key_file": viper.GetString(flagOldKeyFile),
+		"new_key_file": viper.GetString(flagNewKeyFile),
+	}
+	if params["old_key_file"] == "" {
+		passphrase, err := readPassphrase("Current passphrase of the tss store: ", false)
+		if err != nil {
+			return err
+		}
+		params["old_passphrase"] = passphrase
+	}
+	if params["new_key_file"] == "" {
+		passphrase, err := readPassphrase("New passphrase of the tss store: ", true)
+		if err != nil {
+			return err
+		}
+		params["new_passphrase"] = passphrase
+	}
+	res := new(tsstypes.ResultRekey)
+	if err := callRPC("tss_rekey", params, res); err != nil {
+		return err
+	}
+	printJSON(res)
+	return nil
+}
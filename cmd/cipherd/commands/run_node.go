@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	nm "CipherMachine/node"
+	"CipherMachine/threshold"
+)
+
+// AddNodeFlags exposes some common configuration options on the command-line
+// These are exposed for convenience of commands embedding a node
+func AddNodeFlags(cmd *cobra.Command) {
+	// bind flags
+	cmd.Flags().String("moniker", config.Moniker, "Node Name")
+
+	// rpc flags
+	cmd.Flags().String("rpc.laddr", config.RPC.ListenAddress, "RPC listen address. Port required")
+	cmd.Flags().String("rpc.grpc_laddr", config.RPC.GRPCListenAddress, "GRPC listen address of the TssService. Port required")
+	cmd.Flags().Bool("rpc.unsafe", config.RPC.Unsafe, "Enabled unsafe rpc methods, export-share, import-share and rekey need them")
+
+	// p2p flags
+	cmd.Flags().String("p2p.laddr", config.P2P.ListenAddress, "Node listen address. (0.0.0.0:0 means any interface, any port)")
+	cmd.Flags().String("p2p.seeds", config.P2P.Seeds, "Comma-delimited ID@host:port seed nodes")
+	cmd.Flags().String("p2p.persistent_peers", config.P2P.PersistentPeers, "Comma-delimited ID@host:port persistent peers")
+	cmd.Flags().Bool("p2p.pex", config.P2P.PexReactor, "Enable/disable Peer-Exchange")
+	cmd.Flags().String("p2p.private_peer_ids", config.P2P.PrivatePeerIDs, "Comma-delimited private peer IDs")
+
+	//tls config
+	cmd.Flags().Bool("p2p.tls_option", config.P2P.TLSOption, "Enable/disable tls_option")
+	cmd.Flags().Int("tls_config.bind_address_port", config.TLSConfig.BindAddressPort, "tls listen port")
+
+	// tss flags
+	cmd.Flags().String("tss.key_file", config.Tss.KeyFile, "Key file of the tss store, else the passphrase is read from "+threshold.PassphraseEnv)
+}
+
+// NewRunNodeCmd returns the command that allows the CLI to start a node.
+func NewRunNodeCmd(nodeProvider nm.NodeProvider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Run the node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := nodeProvider(config, logger)
+			if err != nil {
+				return fmt.Errorf("Failed to create node: %v", err)
+			}
+
+			// Stop upon receiving SIGTERM or CTRL-C.
+			cmn.TrapSignal(logger, func() {
+				if n.IsRunning() {
+					n.Stop()
+				}
+			})
+
+			if err := n.Start(); err != nil {
+				return fmt.Errorf("Failed to start node: %v", err)
+			}
+			logger.Info("Started node", "nodeInfo", n.NodeInfo())
+
+			// Run forever.
+			select {}
+		},
+	}
+
+	AddNodeFlags(cmd)
+	return cmd
+}
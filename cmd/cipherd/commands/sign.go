@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	tsstypes "CipherMachine/rpc/core/types"
+)
+
+// SignCmd signs a message with a key of a running node.
+var SignCmd = &cobra.Command{
+	Use:   "sign <key_id> <message>",
+	Short: "Sign a message with a key of the node",
+	Long: `Sign a message with a key of the node. The message is hashed with
+hash_alg first, with hash_alg none it is the digest an ECDSA key signs, or the
+message an EdDSA or Schnorr key signs.`,
+	Args: cobra.ExactArgs(2),
+	RunE: sign,
+}
+
+func init() {
+	addClientFlags(SignCmd)
+	SignCmd.Flags().Bool(flagHex, false, "The message is hex encoded")
+	SignCmd.Flags().String(flagHashAlg, "sha256", "Hash of the message, none, sha256, sha256d or keccak256")
+	SignCmd.Flags().StringSlice("parties", nil, "Node ids of the signing quorum, chosen by the node if empty")
+	SignCmd.Flags().String("path", "", "BIP32 path of the child key that signs, like m/0/7")
+	SignCmd.Flags().Duration(flagTimeout, 2*time.Minute, "How long to wait for the signing")
+}
+
+func sign(cmd *cobra.Command, args []string) error {
+	msg, err := messageArg(args[1])
+	if err != nil {
+		return err
+	}
+	path, err := parsePath(viper.GetString("path"))
+	if err != nil {
+		return err
+	}
+	s := new(tsstypes.ResultSession)
+	err = callRPC("tss_sign", map[string]interface{}{
+		"key_id":   args[0],
+		"msg":      cmn.HexBytes(msg),
+		"hash_alg": viper.GetString(flagHashAlg),
+		"parties":  viper.GetStringSlice("parties"),
+		"path":     path,
+	}, s)
+	if err != nil {
+		return err
+	}
+	s, err = waitSession(s.SessionID)
+	if err != nil {
+		return err
+	}
+	printJSON(s)
+	return nil
+}
@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	cfg "CipherMachine/config"
+	nm "CipherMachine/node"
+	"CipherMachine/p2p"
+	"CipherMachine/threshold"
+	ecdsakeygen "CipherMachine/tsslib/ecdsa/keygen"
+)
+
+var (
+	nNodes        int
+	tssThreshold  int
+	configFile    string
+	outputDir     string
+	nodeDirPrefix string
+
+	hostnames     []string
+	p2pPort       int
+	tlsPort       int
+	portStep      int
+	nPreParams    int
+	preParamsTime time.Duration
+)
+
+const (
+	nodeDirPerm = 0755
+
+	// tssKeyFile is the key file of the tss store of every testnet node,
+	// relative to its home
+	tssKeyFile = "config/tss_key"
+)
+
+func init() {
+	TestnetFilesCmd.Annotations = noHomeAnnotations
+	TestnetFilesCmd.Flags().IntVar(&nNodes, "n", 4,
+		"Number of nodes to initialize the testnet with")
+	TestnetFilesCmd.Flags().IntVar(&tssThreshold, "threshold", 1,
+		"Threshold of the keygens, threshold+1 of the nodes sign")
+	TestnetFilesCmd.Flags().StringVar(&configFile, "config", "",
+		"Config file to use (note some options may be overwritten)")
+	TestnetFilesCmd.Flags().StringVar(&outputDir, "o", "./mytestnet",
+		"Directory to store initialization data for the testnet")
+	TestnetFilesCmd.Flags().StringVar(&nodeDirPrefix, "node-dir-prefix", "node",
+		"Prefix the directory name for each node with (node results in node0, node1, ...)")
+
+	TestnetFilesCmd.Flags().StringArrayVar(&hostnames, "hostname", []string{},
+		"Hostnames of the nodes (use --hostname once per node), all nodes run on 127.0.0.1 if none")
+	TestnetFilesCmd.Flags().IntVar(&p2pPort, "p2p-port", 26656,
+		"P2P port of the first node, its RPC port is the next one")
+	TestnetFilesCmd.Flags().IntVar(&tlsPort, "tls-port", 9001,
+		"TLS bind port of the first node")
+	TestnetFilesCmd.Flags().IntVar(&portStep, "port-step", 100,
+		"Ports of node i are the ports of the first node plus i*port-step, 0 if every node has its own host")
+	TestnetFilesCmd.Flags().IntVar(&nPreParams, "pre-params", 0,
+		"Number of keygen pre-params generated into the store of each node, each takes a while")
+	TestnetFilesCmd.Flags().DurationVar(&preParamsTime, "pre-params-timeout", 30*time.Minute,
+		"Maximum time to generate one pre-params")
+}
+
+// TestnetFilesCmd allows initialisation of files for a local testnet.
+var TestnetFilesCmd = &cobra.Command{
+	Use:   "testnet",
+	Short: "Initialize files for a testnet of n nodes",
+	Long: `testnet will create "n" number of directories and populate each with
+the necessary files (config, node key and private validator, key file of the
+tss store).
+
+The persistent peers of every node are all the nodes, the P2P, RPC and TLS
+ports of the nodes do not conflict and the keygens default to the threshold.
+Strict routability for addresses is turned off in the config file.
+
+Optionally, it will generate keygen pre-params into the store of each node,
+so the first keygens of the testnet do not wait for safe primes.
+
+Example:
+
+	cipherd testnet --n 7 --threshold 4 --o ./output --pre-params 1
+	`,
+	RunE: testnetFiles,
+}
+
+func testnetFiles(cmd *cobra.Command, args []string) error {
+	if len(hostnames) > 0 && len(hostnames) != nNodes {
+		return fmt.Errorf("testnet needs precisely %d hostnames if --hostname parameter is used", nNodes)
+	}
+	if tssThreshold < 1 || tssThreshold >= nNodes {
+		return fmt.Errorf("invalid threshold %d for %d nodes", tssThreshold, nNodes)
+	}
+
+	config := cfg.DefaultConfig()
+
+	// overwrite default config if set and valid
+	if configFile != "" {
+		viper.SetConfigFile(configFile)
+		if err := viper.ReadInConfig(); err != nil {
+			return err
+		}
+		if err := viper.Unmarshal(config); err != nil {
+			return err
+		}
+		if err := config.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	config.Tss.Threshold = tssThreshold
+	config.Tss.KeyFile = tssKeyFile
+
+	for i := 0; i < nNodes; i++ {
+		nodeDir := filepath.Join(outputDir, fmt.Sprintf("%s%d", nodeDirPrefix, i))
+		config.SetRoot(nodeDir)
+
+		err := os.MkdirAll(filepath.Join(nodeDir, "config"), nodeDirPerm)
+		if err != nil {
+			_ = os.RemoveAll(outputDir)
+			return err
+		}
+		err = os.MkdirAll(filepath.Join(nodeDir, "data"), nodeDirPerm)
+		if err != nil {
+			_ = os.RemoveAll(outputDir)
+			return err
+		}
+
+		// generates the key file of the tss store and initializes the store with it
+		if err := initFilesWithConfig(config); err != nil {
+			_ = os.RemoveAll(outputDir)
+			return err
+		}
+	}
+
+	// Gather persistent peer addresses.
+	persistentPeers, err := persistentPeersString(config)
+	if err != nil {
+		_ = os.RemoveAll(outputDir)
+		return err
+	}
+
+	// Overwrite default config.
+	for i := 0; i < nNodes; i++ {
+		nodeDir := filepath.Join(outputDir, fmt.Sprintf("%s%d", nodeDirPrefix, i))
+		config.SetRoot(nodeDir)
+		config.P2P.AddrBookStrict = false
+		config.P2P.AllowDuplicateIP = true
+		config.P2P.PersistentPeers = persistentPeers
+		config.P2P.ListenAddress = fmt.Sprintf("tcp://0.0.0.0:%d", p2pPort+i*portStep)
+		config.RPC.ListenAddress = fmt.Sprintf("tcp://127.0.0.1:%d", p2pPort+1+i*portStep)
+		config.TLSConfig.BindAddressPort = tlsPort + i*portStep
+		config.Moniker = fmt.Sprintf("%s%d", nodeDirPrefix, i)
+
+		cfg.WriteConfigFile(filepath.Join(nodeDir, "config", "config.toml"), config)
+	}
+
+	if nPreParams > 0 {
+		for i := 0; i < nNodes; i++ {
+			nodeDir := filepath.Join(outputDir, fmt.Sprintf("%s%d", nodeDirPrefix, i))
+			config.SetRoot(nodeDir)
+			if err := addPreParams(config, i); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Printf("Successfully initialized %v node directories\n", nNodes)
+	return nil
+}
+
+// addPreParams generates pre-params into the store of the node of config.
+func addPreParams(config *cfg.Config, i int) error {
+	db, err := nm.DefaultDBProvider(&nm.DBContext{ID: "store", Config: config})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	for j := 0; j < nPreParams; j++ {
+		start := time.Now()
+		preParams, err := ecdsakeygen.GeneratePreParams(preParamsTime)
+		if err != nil {
+			return err
+		}
+		secret := threshold.StoreSecret{KeyFile: config.Tss.KeyFilePath()}
+		if err := threshold.AddPreParams(db, secret, preParams); err != nil {
+			return err
+		}
+		fmt.Printf("Generated pre-params %d/%d of node %d in %v\n", j+1, nPreParams, i, time.Since(start))
+	}
+	return nil
+}
+
+func hostname(i int) string {
+	if len(hostnames) > 0 {
+		return hostnames[i]
+	}
+	return "127.0.0.1"
+}
+
+func persistentPeersString(config *cfg.Config) (string, error) {
+	persistentPeers := make([]string, nNodes)
+	for i := 0; i < nNodes; i++ {
+		nodeDir := filepath.Join(outputDir, fmt.Sprintf("%s%d", nodeDirPrefix, i))
+		config.SetRoot(nodeDir)
+		nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+		if err != nil {
+			return "", err
+		}
+		persistentPeers[i] = p2p.IDAddressString(nodeKey.ID(), fmt.Sprintf("%s:%d", hostname(i), p2pPort+i*portStep))
+	}
+	return strings.Join(persistentPeers, ","), nil
+}
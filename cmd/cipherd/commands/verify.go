@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	tsstypes "CipherMachine/rpc/core/types"
+)
+
+// VerifyCmd checks a signature of a key of a running node.
+var VerifyCmd = &cobra.Command{
+	Use:   "verify <key_id> <message> <signature>",
+	Short: "Verify a signature, hex R||S as sign prints it, of a message by a key of the node",
+	Args:  cobra.ExactArgs(3),
+	RunE:  verify,
+}
+
+func init() {
+	addClientFlags(VerifyCmd)
+	VerifyCmd.Flags().Bool(flagHex, false, "The message is hex encoded")
+	VerifyCmd.Flags().String(flagHashAlg, "sha256", "Hash of the message, none, sha256, sha256d or keccak256")
+}
+
+func verify(cmd *cobra.Command, args []string) error {
+	msg, err := messageArg(args[1])
+	if err != nil {
+		return err
+	}
+	sig, err := hexArg(args[2])
+	if err != nil {
+		return err
+	}
+	res := new(tsstypes.ResultVerify)
+	err = callRPC("tss_verify", map[string]interface{}{
+		"key_id":    args[0],
+		"msg":       cmn.HexBytes(msg),
+		"hash_alg":  viper.GetString(flagHashAlg),
+		"signature": cmn.HexBytes(sig),
+	}, res)
+	if err != nil {
+		return err
+	}
+	printJSON(res)
+	if !res.Valid {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
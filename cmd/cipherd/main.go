@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/tendermint/tendermint/libs/cli"
+
+	cmd "CipherMachine/cmd/cipherd/commands"
+	nm "CipherMachine/node"
+)
+
+func main() {
+	rootCmd := cmd.RootCmd
+	rootCmd.AddCommand(
+		cmd.InitFilesCmd,
+		cmd.ShowNodeIDCmd,
+		cmd.KeygenCmd,
+		cmd.SignCmd,
+		cmd.VerifyCmd,
+		cmd.KeysCmd,
+		cmd.ExportShareCmd,
+		cmd.ImportShareCmd,
+		cmd.RekeyCmd,
+		cmd.TestnetFilesCmd,
+	)
+
+	// NOTE:
+	// Users wishing to provide their own DB implementation can copy this
+	// file and use something other than the DefaultNewNode function
+	nodeFunc := nm.DefaultNewNode
+
+	// Create & start node
+	rootCmd.AddCommand(cmd.NewRunNodeCmd(nodeFunc))
+
+	cmd := cli.PrepareBaseCmd(rootCmd, "CIPHER", os.ExpandEnv(filepath.Join("$HOME", ".cipherd")))
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
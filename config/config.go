@@ -20,6 +20,14 @@ const (
 	LogFormatPlain = "plain"
 	// LogFormatJSON is a format for json output
 	LogFormatJSON = "json"
+
+	// UntrustedPeerPolicyNone only records the trust of peers
+	UntrustedPeerPolicyNone = "none"
+	// UntrustedPeerPolicyRefuse refuses sessions with untrusted peers
+	UntrustedPeerPolicyRefuse = "refuse"
+	// UntrustedPeerPolicyDisconnect refuses sessions with untrusted peers and
+	// disconnects them
+	UntrustedPeerPolicyDisconnect = "disconnect"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -69,6 +77,7 @@ type Config struct {
 	Consensus       *ConsensusConfig       `mapstructure:"consensus"`
 	TxIndex         *TxIndexConfig         `mapstructure:"tx_index"`
 	Instrumentation *InstrumentationConfig `mapstructure:"instrumentation"`
+	Tss             *TssConfig             `mapstructure:"tss"`
 }
 
 // DefaultConfig returns a default configuration for a Tendermint node
@@ -83,6 +92,7 @@ func DefaultConfig() *Config {
 		Consensus:       DefaultConsensusConfig(),
 		TxIndex:         DefaultTxIndexConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
+		Tss:             DefaultTssConfig(),
 	}
 }
 
@@ -97,6 +107,7 @@ func TestConfig() *Config {
 		Consensus:       TestConsensusConfig(),
 		TxIndex:         TestTxIndexConfig(),
 		Instrumentation: TestInstrumentationConfig(),
+		Tss:             TestTssConfig(),
 	}
 }
 
@@ -107,6 +118,10 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.P2P.RootDir = root
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
+	// config files written before the [tss] section existed
+	if cfg.Tss != nil {
+		cfg.Tss.RootDir = root
+	}
 	return cfg
 }
 
@@ -131,6 +146,13 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return errors.Wrap(err, "Error in [consensus] section")
 	}
+	// config files written before the [tss] section existed have none, the
+	// reactor takes the defaults
+	if cfg.Tss != nil {
+		if err := cfg.Tss.ValidateBasic(); err != nil {
+			return errors.Wrap(err, "Error in [tss] section")
+		}
+	}
 	return errors.Wrap(
 		cfg.Instrumentation.ValidateBasic(),
 		"Error in [instrumentation] section",
@@ -321,7 +343,7 @@ type RPCConfig struct {
 	CORSAllowedHeaders []string `mapstructure:"cors_allowed_headers"`
 
 	// TCP or UNIX socket address for the gRPC server to listen on
-	// NOTE: This server only supports /broadcast_tx_commit
+	// NOTE: This server serves the TssService of rpc/grpc/types.proto
 	GRPCListenAddress string `mapstructure:"grpc_laddr"`
 
 	// Maximum number of simultaneous connections.
@@ -1009,6 +1031,103 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	return nil
 }
 
+//-----------------------------------------------------------------------------
+// TssConfig
+
+// TssConfig defines the configuration for the threshold signing sessions.
+type TssConfig struct {
+	RootDir string `mapstructure:"home"`
+
+	// Maximum time a keygen, signing or resharing session may run before
+	// it is aborted.
+	SessionTimeout time.Duration `mapstructure:"session_timeout"`
+
+	// Threshold of the keygens that do not ask for one, 0 means they must.
+	Threshold int `mapstructure:"threshold"`
+
+	// Peers named as culprits of failed sessions lose trust. A peer whose
+	// trust score (0-100) drops below TrustThreshold is untrusted.
+	TrustThreshold int `mapstructure:"trust_threshold"`
+
+	// What to do with untrusted peers: "none", "refuse" or "disconnect".
+	UntrustedPeerPolicy string `mapstructure:"untrusted_peer_policy"`
+
+	// Sign every outbound msg with the node key and refuse unsigned inbound
+	// msgs.
+	SignMessages bool `mapstructure:"sign_messages"`
+
+	// Number of keygen pre-params (safe primes and Paillier keys) generated
+	// ahead of time in the background, 0 disables the pool.
+	PreParamsPoolSize int `mapstructure:"pre_params_pool_size"`
+
+	// Number of ECDSA presignatures kept for every key the node signs with,
+	// made in the background while no session runs. 0 disables the pool.
+	PresignaturePoolSize int `mapstructure:"presignature_pool_size"`
+
+	// Key file the key shares in the store are encrypted with. If empty, the
+	// passphrase is read from the CIPHER_TSS_PASSPHRASE environment variable.
+	KeyFile string `mapstructure:"key_file"`
+}
+
+// DefaultTssConfig returns a default configuration for the threshold
+// signing sessions.
+func DefaultTssConfig() *TssConfig {
+	return &TssConfig{
+		SessionTimeout:       10 * time.Minute,
+		TrustThreshold:       50,
+		UntrustedPeerPolicy:  UntrustedPeerPolicyNone,
+		PreParamsPoolSize:    2,
+		PresignaturePoolSize: 0,
+	}
+}
+
+// TestTssConfig returns a configuration for testing the threshold signing
+// sessions.
+func TestTssConfig() *TssConfig {
+	return &TssConfig{
+		SessionTimeout:       2 * time.Minute,
+		TrustThreshold:       50,
+		UntrustedPeerPolicy:  UntrustedPeerPolicyNone,
+		PreParamsPoolSize:    0,
+		PresignaturePoolSize: 0,
+	}
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TssConfig) ValidateBasic() error {
+	if cfg.SessionTimeout <= 0 {
+		return errors.New("session_timeout must be positive")
+	}
+	if cfg.Threshold < 0 {
+		return errors.New("threshold can't be negative")
+	}
+	if cfg.TrustThreshold < 0 || cfg.TrustThreshold > 100 {
+		return errors.New("trust_threshold must be between 0 and 100")
+	}
+	switch cfg.UntrustedPeerPolicy {
+	case UntrustedPeerPolicyNone, UntrustedPeerPolicyRefuse, UntrustedPeerPolicyDisconnect:
+	default:
+		return errors.New("unknown untrusted_peer_policy (must be 'none', 'refuse' or 'disconnect')")
+	}
+	if cfg.PreParamsPoolSize < 0 {
+		return errors.New("pre_params_pool_size can't be negative")
+	}
+	if cfg.PresignaturePoolSize < 0 {
+		return errors.New("presignature_pool_size can't be negative")
+	}
+	return nil
+}
+
+// KeyFilePath returns the full path to the key file of the store, empty if
+// none is set.
+func (cfg *TssConfig) KeyFilePath() string {
+	if cfg.KeyFile == "" {
+		return ""
+	}
+	return rootify(cfg.KeyFile, cfg.RootDir)
+}
+
 //-----------------------------------------------------------------------------
 // Utils
 
@@ -33,6 +33,10 @@ func TestConfigValidateBasic(t *testing.T) {
 	cfg := DefaultConfig()
 	assert.NoError(t, cfg.ValidateBasic())
 
+	// a config without a [tss] section is valid
+	cfg.Tss = nil
+	assert.NoError(t, cfg.ValidateBasic())
+
 	// tamper with timeout_propose
 	cfg.Consensus.TimeoutPropose = -10 * time.Second
 	assert.Error(t, cfg.ValidateBasic())
@@ -166,3 +170,35 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
 }
+
+func TestTssConfigValidateBasic(t *testing.T) {
+	cfg := TestTssConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// tamper with the session timeout
+	cfg.SessionTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	// tamper with the default threshold
+	cfg = TestTssConfig()
+	cfg.Threshold = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	// tamper with the trust settings
+	cfg = TestTssConfig()
+	cfg.TrustThreshold = 101
+	assert.Error(t, cfg.ValidateBasic())
+	cfg = TestTssConfig()
+	cfg.UntrustedPeerPolicy = "ban"
+	assert.Error(t, cfg.ValidateBasic())
+
+	// tamper with the pre-params pool size
+	cfg = TestTssConfig()
+	cfg.PreParamsPoolSize = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	// tamper with the presignature pool size
+	cfg = TestTssConfig()
+	cfg.PresignaturePoolSize = -1
+	assert.Error(t, cfg.ValidateBasic())
+}
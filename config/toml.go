@@ -152,7 +152,7 @@ cors_allowed_methods = [{{ range .RPC.CORSAllowedMethods }}{{ printf "%q, " . }}
 cors_allowed_headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}{{end}}]
 
 # TCP or UNIX socket address for the gRPC server to listen on
-# NOTE: This server only supports /broadcast_tx_commit
+# NOTE: This server serves the TssService of rpc/grpc/types.proto
 grpc_laddr = "{{ .RPC.GRPCListenAddress }}"
 
 # Maximum number of simultaneous connections.
@@ -407,6 +407,46 @@ max_open_connections = {{ .Instrumentation.MaxOpenConnections }}
 
 # Instrumentation namespace
 namespace = "{{ .Instrumentation.Namespace }}"
+
+##### threshold signing configuration options #####
+[tss]
+
+# Maximum time a keygen, signing or resharing session may run before it
+# is aborted
+session_timeout = "{{ .Tss.SessionTimeout }}"
+
+# Threshold of the keygens that do not ask for one, threshold+1 of the
+# persistent peers sign. 0 means every keygen must ask for its threshold
+threshold = {{ .Tss.Threshold }}
+
+# Peers named as culprits of failed sessions lose trust. A peer whose trust
+# score (0-100) drops below trust_threshold is untrusted
+trust_threshold = {{ .Tss.TrustThreshold }}
+
+# What to do with untrusted peers:
+#   1) "none" - only record the trust of peers
+#   2) "refuse" - refuse keygen, signing and resharing sessions with them
+#   3) "disconnect" - refuse sessions with them and drop the connection
+untrusted_peer_policy = "{{ .Tss.UntrustedPeerPolicy }}"
+
+# Sign every outbound msg with the node key and refuse unsigned inbound msgs.
+# Signed msgs stay attributable to their sender when relayed or recorded
+sign_messages = {{ .Tss.SignMessages }}
+
+# Number of keygen pre-params (safe primes and Paillier keys) generated ahead
+# of time in the background, each is used by one keygen. 0 disables the pool
+# and every keygen generates its own, which takes minutes
+pre_params_pool_size = {{ .Tss.PreParamsPoolSize }}
+
+# Number of ECDSA presignatures kept for every key the node signs with, made
+# in the background while no session runs. A signing with a presignature
+# takes a single round. 0 disables the pool
+presignature_pool_size = {{ .Tss.PresignaturePoolSize }}
+
+# Key file the key shares in the store are encrypted with, 32 hex encoded
+# bytes. If empty, the passphrase is read from the CIPHER_TSS_PASSPHRASE
+# environment variable. Without either the store stays locked
+key_file = "{{ js .Tss.KeyFile }}"
 `
 
 /****** these are for test settings ***********/
@@ -1,7 +1,9 @@
 package node
 
 import (
+	"context"
 	"CipherMachine/tsslib/common"
+	tsscrypto "CipherMachine/tsslib/crypto"
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/rs/cors"
@@ -11,7 +13,6 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/privval"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
-	grpccore "github.com/tendermint/tendermint/rpc/grpc"
 	rpcserver "github.com/tendermint/tendermint/rpc/lib/server"
 	"github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tendermint/version"
@@ -26,6 +27,7 @@ import (
 	"CipherMachine/p2p/tls"
 	"CipherMachine/threshold"
 	rpccore "CipherMachine/rpc/core"
+	grpccore "CipherMachine/rpc/grpc"
 	"strings"
 	"time"
 )
@@ -66,6 +68,11 @@ func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
 	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir()), nil
 }
 
+// NodeProvider takes a config and a logger and returns a ready to go Node.
+type NodeProvider func(*cfg.Config, log.Logger) (*Node, error)
+
+// DefaultNewNode returns a Node with the node key and the private validator
+// of the config, generating them if missing, and the default store.
 func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	// Generate node PrivKey
 	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
@@ -217,6 +224,11 @@ func (n *Node) OnStart() error {
 
 	n.isListening = true
 
+	// Unlock the key shares before any session can start.
+	if err := n.unlockTssStore(); err != nil {
+		return errors.Wrap(err, "could not unlock the tss store")
+	}
+
 	// Start the switch (the P2P server).
 	err = n.sw.Start()
 	if err != nil {
@@ -232,6 +244,25 @@ func (n *Node) OnStart() error {
 	return nil
 }
 
+// OnStop stops the switch and closes the transport and the rpc listeners.
+func (n *Node) OnStop() {
+	n.BaseService.OnStop()
+
+	n.Logger.Info("Stopping Node")
+	n.sw.Stop()
+	if err := n.transport.Close(); err != nil {
+		n.Logger.Error("Error closing transport", "err", err)
+	}
+	n.isListening = false
+
+	for _, l := range n.rpcListeners {
+		n.Logger.Info("Closing rpc listener", "listener", l)
+		if err := l.Close(); err != nil {
+			n.Logger.Error("Error closing listener", "listener", l, "err", err)
+		}
+	}
+}
+
 func(n *Node) StartTLS() {
 	var newCfg = n.Config()
 	p2p.SetP2PConfig(newCfg)
@@ -278,6 +309,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		wmLogger := rpcLogger.With("protocol", "websocket")
 		wm := rpcserver.NewWebsocketManager(rpccore.Routes, coreCodec,
 			rpcserver.OnDisconnect(func(remoteAddr string) {
+				rpccore.UnsubscribeAll(remoteAddr)
 			}),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
 		)
@@ -322,7 +354,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		listeners[i] = listener
 	}
 
-	// we expose a simplified api over grpc for convenience to app devs
+	// we expose the tss api over grpc for typed clients of backend services
 	grpcListenAddr := n.config.RPC.GRPCListenAddress
 	if grpcListenAddr != "" {
 		config := rpcserver.DefaultConfig()
@@ -344,6 +376,7 @@ func (n *Node) ConfigureRPC() {
 	rpccore.SetP2PPeers(n.sw)
 	rpccore.SetP2PTransport(n)
 	rpccore.SetAddrBook(n.addrBook)
+	rpccore.SetTssReactor(n.sw.Reactor("tss").(*threshold.TssReactor))
 	rpccore.SetLogger(n.Logger.With("module", "rpc"))
 	rpccore.SetConfig(*n.config.RPC)
 }
@@ -363,31 +396,153 @@ func (n *Node) NodeInfo() p2p.NodeInfo {
 	return n.nodeInfo
 }
 
-// Keygen exported, used in client
-func (n *Node) Keygen(shares int, sid threshold.SessionID) chan struct{}{
-	return n.sw.Reactor("tss").(*threshold.TssReactor).Keygen(shares, sid)
+// Keygen exported, used in client. It blocks until the key is generated, ctx
+// is done or the session times out.
+func (n *Node) Keygen(ctx context.Context, req threshold.KeygenRequest) *threshold.KeygenResult {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).Keygen(ctx, req)
 }
 
-// Signing exported, used in client
-func (n *Node) Signing(msg *big.Int, sid threshold.SessionID) (chan common.SignatureData, error) {
-	resCh, err := n.sw.Reactor("tss").(*threshold.TssReactor).Signing(msg, sid)
-	if err != nil {
-		return nil, err
-	}
-	return resCh, nil
+// Sign exported, used in client. It blocks until the signature is done, ctx
+// is done or the session times out.
+func (n *Node) Sign(ctx context.Context, req threshold.SignRequest) *threshold.SignResult {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).Sign(ctx, req)
+}
+
+// SignBatch signs a batch of digests in one session, used in client. The
+// results are in the order of the digests, it blocks like Sign.
+func (n *Node) SignBatch(ctx context.Context, req threshold.BatchSignRequest) []*threshold.SignResult {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).SignBatch(ctx, req)
+}
+
+// Presign makes presignatures of an ECDSA key for this node, used in client.
+// It blocks until they are stored, ctx is done or the session times out.
+func (n *Node) Presign(ctx context.Context, req threshold.PresignRequest) *threshold.PresignResult {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).Presign(ctx, req)
+}
+
+// PresignatureCount returns the presignatures of keyID left, used in client
+func (n *Node) PresignatureCount(keyID threshold.KeyID) int {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).PresignatureCount(keyID)
+}
+
+// SignDigest signs a 32 byte digest, used in client. It blocks like Sign.
+func (n *Node) SignDigest(ctx context.Context, keyID threshold.KeyID, digest [32]byte) *threshold.SignResult {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).SignDigest(ctx, keyID, digest)
+}
+
+// SignMessage hashes data with alg and signs the digest, used in client. It
+// blocks like Sign.
+func (n *Node) SignMessage(ctx context.Context, keyID threshold.KeyID, data []byte, alg threshold.HashAlg) *threshold.SignResult {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).SignMessage(ctx, keyID, data, alg)
+}
+
+// VerifyDigest checks a signature of a 32 byte digest, used in client
+func (n *Node) VerifyDigest(keyID threshold.KeyID, digest [32]byte, signature common.SignatureData) error {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).VerifyDigest(keyID, digest, signature)
+}
+
+// VerifyMessage checks a signature of data hashed with alg, used in client
+func (n *Node) VerifyMessage(keyID threshold.KeyID, data []byte, alg threshold.HashAlg, signature common.SignatureData) error {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).VerifyMessage(keyID, data, alg, signature)
 }
 
 // Verify exported, used in client
-func (n *Node) Verify(msg *big.Int, sid threshold.SessionID, signature common.SignatureData) error {
-	if err := n.sw.Reactor("tss").(*threshold.TssReactor).Verify(msg, sid, signature); err != nil {
+func (n *Node) Verify(msg *big.Int, keyID threshold.KeyID, signature common.SignatureData) error {
+	if err := n.sw.Reactor("tss").(*threshold.TssReactor).Verify(msg, keyID, signature); err != nil {
 		return err
 	}
 	return nil
 }
 
 // Resharing exported, used in client
-func (n *Node) Resharing() {
-	return
+func (n *Node) Resharing(keyID threshold.KeyID, newPeers []string, newThreshold int) (chan *threshold.ResharingResult, error) {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).Resharing(keyID, newPeers, newThreshold)
+}
+
+// TrustScore returns the trust score (0-100) of peer peerID, used in client
+func (n *Node) TrustScore(peerID string) int {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).TrustScore(peerID)
+}
+
+// SessionCount returns the number of tss sessions running, used in client
+func (n *Node) SessionCount() int {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).SessionCount()
+}
+
+// unlockTssStore unlocks the tss store with the key file of the config or the
+// passphrase in the environment. Without either the store stays locked.
+func (n *Node) unlockTssStore() error {
+	var secret threshold.StoreSecret
+	if n.config.Tss != nil && n.config.Tss.KeyFile != "" {
+		secret.KeyFile = n.config.Tss.KeyFilePath()
+	} else {
+		secret.Passphrase = os.Getenv(threshold.PassphraseEnv)
+	}
+	if secret.KeyFile == "" && secret.Passphrase == "" {
+		n.Logger.Error("tss store is locked, set key_file in [tss] or " + threshold.PassphraseEnv)
+		return nil
+	}
+	return n.Unlock(secret)
+}
+
+// Unlock unlocks the key shares of the tss store, used in client
+func (n *Node) Unlock(secret threshold.StoreSecret) error {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).Unlock(secret)
+}
+
+// Rekey replaces the passphrase or key file of the tss store, used in client
+func (n *Node) Rekey(oldSecret, newSecret threshold.StoreSecret) error {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).Rekey(oldSecret, newSecret)
+}
+
+// ExportShare writes the encrypted share of keyID to a new backup file, used in client
+func (n *Node) ExportShare(keyID threshold.KeyID, path string, passphrase string) error {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).ExportShare(keyID, path, passphrase)
+}
+
+// ImportShare restores a share from a backup file, used in client
+func (n *Node) ImportShare(path string, passphrase string) (threshold.KeyID, error) {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).ImportShare(path, passphrase)
+}
+
+// ListKeys returns the keys the node holds, used in client
+func (n *Node) ListKeys() ([]*threshold.KeyInfo, error) {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).ListKeys()
+}
+
+// KeyInfo describes the key of keyID, used in client
+func (n *Node) KeyInfo(keyID threshold.KeyID) (*threshold.KeyInfo, error) {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).KeyInfo(keyID)
+}
+
+// RetireKey makes the node refuse signing with keyID, used in client
+func (n *Node) RetireKey(keyID threshold.KeyID) error {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).RetireKey(keyID)
+}
+
+// DeleteKey deletes a retired key, confirm is its public key in hex, used in client
+func (n *Node) DeleteKey(keyID threshold.KeyID, confirm string) error {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).DeleteKey(keyID, confirm)
+}
+
+// DerivePubKey returns the public key of the non-hardened BIP32 child at path, used in client
+func (n *Node) DerivePubKey(keyID threshold.KeyID, path []uint32) (*tsscrypto.ECPoint, error) {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).DerivePubKey(keyID, path)
+}
+
+// TaprootOutputKey returns the taproot output key of a Schnorr key for a script tree, used in client
+func (n *Node) TaprootOutputKey(keyID threshold.KeyID, merkleRoot []byte) (*tsscrypto.ECPoint, error) {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).TaprootOutputKey(keyID, merkleRoot)
+}
+
+// ChainCode returns the BIP32 chain code of a key, used in client
+func (n *Node) ChainCode(keyID threshold.KeyID) ([]byte, error) {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).ChainCode(keyID)
+}
+
+// PreParamsPoolSize returns the number of keygen pre-params ready for use, used in client
+func (n *Node) PreParamsPoolSize() int {
+	return n.sw.Reactor("tss").(*threshold.TssReactor).PreParamsPoolSize()
 }
 
 func initDB(config *cfg.Config, dbProvider DBProvider) (storeDB dbm.DB, err error) {
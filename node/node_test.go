@@ -1,10 +1,12 @@
 package node
 
 import (
+	"context"
 	"CipherMachine/config"
 	"CipherMachine/threshold"
 	"CipherMachine/tsslib/ecdsa/keygen"
 	"encoding/json"
+	"fmt"
 	create "github.com/ci123chain/ci123chain/sdk/init"
 	"github.com/ci123chain/ci123chain/sdk/validator"
 	"github.com/spf13/viper"
@@ -105,39 +107,39 @@ func TestRunNode3(t *testing.T) {
 	}
 }
 
-//本地启动3个节点，并进行门限签名初始化
-func TestKeygen(t *testing.T) {
-	n, _, _ := start3node(t)
-	time.Sleep(10 * time.Second)
-	sessionID := threshold.SessionID("session-1")
-
-	//传入门限数和sessionID
-	resCh := n.Keygen(2, sessionID)
-	if resCh == nil {
-		return
+//进程内启动3个节点，依次进行门限签名初始化、签名、将密钥重组给节点1、2并把门限数改为1，再用新分片签名
+func TestKeygenSignResharing(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
 	}
+	tn := startTestNet(t, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	keyID := threshold.KeyID("key-1")
 
-	select {
-	case <-resCh:
-		//wait for n2, n3 done, just for test
-		time.Sleep(2 * time.Second)
-	}
-}
+	res, err := tn.Keygen(ctx, 0, threshold.KeygenRequest{KeyID: keyID, Threshold: 2})
+	require.NoError(t, err)
+	t.Logf("pubkey x: %s, y: %s", res.PubKey.X(), res.PubKey.Y())
 
-//本地启动3个节点，并进行门限签名，需提前调用keygen
-func TestSigning(t *testing.T) {
-	n, _, _ := start3node(t)
-	time.Sleep(10 * time.Second)
-	sessionID := threshold.SessionID("session-1")
 	msg := big.NewInt(42)
-	resCh, err := n.Signing(msg, sessionID)
+	sres, err := tn.Sign(ctx, 1, threshold.SignRequest{KeyID: keyID, Msg: msg})
 	require.NoError(t, err)
-
-	select {
-	case signature := <-resCh:
-		err := n.Verify(msg, sessionID, signature)
-		require.NoError(t, err)
+	for _, n := range tn.Nodes {
+		require.NoError(t, n.Verify(msg, keyID, *sres.Signature))
 	}
+
+	_, err = tn.Resharing(ctx, 0, keyID, []int{0, 1}, 1)
+	require.NoError(t, err)
+	info, err := tn.Nodes[1].KeyInfo(keyID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, tn.IDs(0, 1), info.Committee)
+
+	// the new committee signs for the same public key
+	msg = big.NewInt(43)
+	sres, err = tn.Sign(ctx, 1, threshold.SignRequest{KeyID: keyID, Msg: msg})
+	require.NoError(t, err)
+	require.NoError(t, tn.Nodes[0].Verify(msg, keyID, *sres.Signature))
+	require.Equal(t, []byte(res.PubKey.SerializeCompressed()), []byte(info.PubKey))
 }
 
 //测试从文件中读取saveData
@@ -161,31 +163,26 @@ func writeConfigFile(root string, file *create.InitFiles) {
 	ioutil.WriteFile(filepath.Join(root, "data/priv_validator_state.json"), file.PrivValidatorStateBytes, os.ModePerm)
 }
 
-func start3node(t *testing.T) (n, n2, n3 *Node){
-	root := "../test1"
-	//defer os.RemoveAll(root)
-	n = getNewNode(root)
-	err := n.Start()
-	require.NoError(t, err)
-
-	t.Logf("Started node %v", n.sw.NodeInfo())
-
-	root2 := "../test2"
-	//defer os.RemoveAll(root2)
-	n2 = getNewNode(root2)
-	err = n2.Start()
+// startTestNet starts n nodes in this process, the keygen pre-params are read
+// from the test fixtures of the tss reactor.
+func startTestNet(t *testing.T, n int) *TestNet {
+	preParams := make([][]*keygen.LocalPreParams, n)
+	for i := range preParams {
+		preParams[i] = []*keygen.LocalPreParams{loadTestPreParams(t, i)}
+	}
+	tn, err := MakeTestNet(n, preParams, log.TestingLogger())
 	require.NoError(t, err)
+	t.Cleanup(tn.Stop)
+	require.NoError(t, tn.Start())
+	return tn
+}
 
-	t.Logf("Started node %v", n2.sw.NodeInfo())
-
-	root3 := "../test3"
-	//defer os.RemoveAll(root3)
-	n3 = getNewNode(root3)
-	err = n3.Start()
+func loadTestPreParams(t *testing.T, i int) *keygen.LocalPreParams {
+	bz, err := ioutil.ReadFile(filepath.Join("../threshold/test", fmt.Sprintf("preparams_%d.json", i)))
 	require.NoError(t, err)
-
-	t.Logf("Started node %v", n3.sw.NodeInfo())
-	return
+	preParams := new(keygen.LocalPreParams)
+	require.NoError(t, json.Unmarshal(bz, preParams))
+	return preParams
 }
 
 func getNewNode(root string) *Node {
@@ -0,0 +1,273 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "CipherMachine/config"
+	"CipherMachine/p2p"
+	"CipherMachine/threshold"
+	"CipherMachine/tsslib/ecdsa/keygen"
+)
+
+const (
+	// testTssKeyFile is the key file of the tss store of every test node,
+	// relative to its home
+	testTssKeyFile = "config/tss_key"
+
+	// defaultTestNetWait is how long the helpers of a TestNet wait for the
+	// nodes to agree after a session
+	defaultTestNetWait = 30 * time.Second
+)
+
+//------------------------------------------------------------------
+// Runs nodes in this process. Used for testing.
+
+// TestNet is a set of nodes running in this process, each a persistent peer
+// of the others. The stores of the nodes are in memory, the homes are temp
+// dirs and the nodes listen on free local ports.
+type TestNet struct {
+	Nodes []*Node
+	// how long the helpers wait for the nodes to agree after a session
+	Wait time.Duration
+
+	roots []string
+}
+
+// MakeTestNet returns n nodes that are not started yet. preParams[i] are put
+// into the pre-params pool of node i, each is taken by one keygen or by one
+// resharing the node joins as a new member. A node without pre-params
+// generates them at keygen, which takes minutes.
+func MakeTestNet(n int, preParams [][]*keygen.LocalPreParams, logger log.Logger) (*TestNet, error) {
+	tn := &TestNet{Wait: defaultTestNetWait}
+	nodeKeys := make([]*p2p.NodeKey, n)
+	listenAddrs := make([]string, n)
+	peers := make([]string, n)
+	for i := 0; i < n; i++ {
+		port, err := cmn.GetFreePort()
+		if err != nil {
+			return nil, err
+		}
+		nodeKeys[i] = &p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}
+		listenAddrs[i] = fmt.Sprintf("127.0.0.1:%d", port)
+		peers[i] = p2p.IDAddressString(nodeKeys[i].ID(), listenAddrs[i])
+	}
+
+	for i := 0; i < n; i++ {
+		root, err := ioutil.TempDir("", fmt.Sprintf("testnet_node%d_", i))
+		if err != nil {
+			tn.cleanup()
+			return nil, err
+		}
+		tn.roots = append(tn.roots, root)
+		config := testNetConfig(root, i, listenAddrs[i], strings.Join(peers, ","))
+		if err := initTestNetHome(config); err != nil {
+			tn.cleanup()
+			return nil, err
+		}
+
+		storeDB := dbm.NewMemDB()
+		secret := threshold.StoreSecret{KeyFile: config.Tss.KeyFilePath()}
+		if err := threshold.InitStore(storeDB, secret); err != nil {
+			tn.cleanup()
+			return nil, errors.Wrapf(err, "could not initialize the tss store of node %d", i)
+		}
+		if i < len(preParams) && len(preParams[i]) > 0 {
+			if err := threshold.AddPreParams(storeDB, secret, preParams[i]...); err != nil {
+				tn.cleanup()
+				return nil, errors.Wrapf(err, "could not add pre-params of node %d", i)
+			}
+		}
+		dbProvider := func(*DBContext) (dbm.DB, error) { return storeDB, nil }
+		node, err := NewNode(config, types.NewMockPV(), nodeKeys[i], dbProvider, logger.With("node", i))
+		if err != nil {
+			tn.cleanup()
+			return nil, err
+		}
+		tn.Nodes = append(tn.Nodes, node)
+	}
+	return tn, nil
+}
+
+// testNetConfig returns the config of node i, the rpc servers are off as
+// they serve a single node per process.
+func testNetConfig(root string, i int, listenAddr, persistentPeers string) *cfg.Config {
+	config := cfg.TestConfig().SetRoot(root)
+	config.Moniker = fmt.Sprintf("node%d", i)
+	config.RPC.ListenAddress = ""
+	config.RPC.GRPCListenAddress = ""
+	config.P2P.ListenAddress = "tcp://" + listenAddr
+	config.P2P.PersistentPeers = persistentPeers
+	config.P2P.AddrBookStrict = false
+	config.P2P.AllowDuplicateIP = true
+	config.Tss.KeyFile = testTssKeyFile
+	return config
+}
+
+func initTestNetHome(config *cfg.Config) error {
+	for _, dir := range []string{"config", "data"} {
+		if err := os.MkdirAll(filepath.Join(config.RootDir, dir), 0755); err != nil {
+			return err
+		}
+	}
+	return threshold.GenerateKeyFile(config.Tss.KeyFilePath())
+}
+
+// Start starts the nodes and waits until every node is connected to all the
+// others.
+func (tn *TestNet) Start() error {
+	for i, n := range tn.Nodes {
+		if err := n.Start(); err != nil {
+			return errors.Wrapf(err, "could not start node %d", i)
+		}
+	}
+	return tn.waitFor("the nodes to connect", func() bool {
+		for _, n := range tn.Nodes {
+			if n.sw.Peers().Size() != len(tn.Nodes)-1 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Stop stops the running nodes and removes their homes.
+func (tn *TestNet) Stop() {
+	for _, n := range tn.Nodes {
+		if n.IsRunning() {
+			n.Stop()
+		}
+	}
+	tn.cleanup()
+}
+
+func (tn *TestNet) cleanup() {
+	for _, root := range tn.roots {
+		os.RemoveAll(root)
+	}
+	tn.roots = nil
+}
+
+// IDs returns the node ids of the nodes of the indexes, of all the nodes if
+// none are given.
+func (tn *TestNet) IDs(indexes ...int) []string {
+	if len(indexes) == 0 {
+		for i := range tn.Nodes {
+			indexes = append(indexes, i)
+		}
+	}
+	ids := make([]string, len(indexes))
+	for j, i := range indexes {
+		ids[j] = string(tn.Nodes[i].nodeKey.ID())
+	}
+	return ids
+}
+
+// Keygen runs req from node i and waits until every node stored its share
+// and ended the session.
+func (tn *TestNet) Keygen(ctx context.Context, i int, req threshold.KeygenRequest) (*threshold.KeygenResult, error) {
+	res := tn.Nodes[i].Keygen(ctx, req)
+	if res.Err != nil {
+		return res, res.Err
+	}
+	err := tn.waitFor(fmt.Sprintf("the shares of key %s", req.KeyID), func() bool {
+		for _, n := range tn.Nodes {
+			if _, err := n.KeyInfo(req.KeyID); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return res, err
+	}
+	return res, tn.WaitIdle()
+}
+
+// Sign runs req from node i and waits until every node ended the session.
+func (tn *TestNet) Sign(ctx context.Context, i int, req threshold.SignRequest) (*threshold.SignResult, error) {
+	res := tn.Nodes[i].Sign(ctx, req)
+	if res.Err != nil {
+		return res, res.Err
+	}
+	return res, tn.WaitIdle()
+}
+
+// Resharing reshares keyID from node i to the nodes of newIndexes and waits
+// until the new committee stored the new shares, the nodes left out deleted
+// theirs and every node ended the session.
+func (tn *TestNet) Resharing(ctx context.Context, i int, keyID threshold.KeyID, newIndexes []int, newThreshold int) (*threshold.ResharingResult, error) {
+	resCh, err := tn.Nodes[i].Resharing(keyID, tn.IDs(newIndexes...), newThreshold)
+	if err != nil {
+		return nil, err
+	}
+	var res *threshold.ResharingResult
+	select {
+	case res = <-resCh:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if res.Err != nil {
+		return res, res.Err
+	}
+
+	inNew := make(map[int]bool, len(newIndexes))
+	for _, j := range newIndexes {
+		inNew[j] = true
+	}
+	err = tn.waitFor(fmt.Sprintf("the new shares of key %s", keyID), func() bool {
+		for j, n := range tn.Nodes {
+			info, err := n.KeyInfo(keyID)
+			if !inNew[j] {
+				if err == nil {
+					return false
+				}
+				continue
+			}
+			if err != nil || info.Threshold != newThreshold || len(info.Committee) != len(newIndexes) {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return res, err
+	}
+	return res, tn.WaitIdle()
+}
+
+// WaitIdle waits until no node runs a session.
+func (tn *TestNet) WaitIdle() error {
+	return tn.waitFor("the sessions to end", func() bool {
+		for _, n := range tn.Nodes {
+			if n.SessionCount() != 0 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// waitFor polls cond until it holds or tn.Wait passed.
+func (tn *TestNet) waitFor(what string, cond func() bool) error {
+	deadline := time.Now().Add(tn.Wait)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil
+}
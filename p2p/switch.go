@@ -213,6 +213,12 @@ func (sw *Switch) SetNodeKey(nodeKey *NodeKey) {
 	sw.nodeKey = nodeKey
 }
 
+// NodeKey returns the switch's private key.
+// NOTE: Not goroutine safe.
+func (sw *Switch) NodeKey() *NodeKey {
+	return sw.nodeKey
+}
+
 //---------------------------------------------------------------------
 // Service start/stop
 
@@ -774,7 +780,7 @@ func (sw *Switch) addPeer(p Peer) error {
 	// so that if Receive errors, we will find the peer and remove it.
 	// Add should not err since we already checked peers.Has().
 	if err := sw.peers.Add(p); err != nil {
-		sw.Logger.Error("switch peer", "list", sw.peers.list, "localnode", sw.nodeInfo.ID(), "err", err)
+		sw.Logger.Error("switch peer", "list", sw.peers.List(), "localnode", sw.nodeInfo.ID(), "err", err)
 		return err
 	}
 	sw.metrics.Peers.Add(float64(1))
@@ -785,6 +791,6 @@ func (sw *Switch) addPeer(p Peer) error {
 	}
 
 	sw.Logger.Info("Added peer", "peer", p, "localnode", sw.nodeInfo.ID())
-	sw.Logger.Debug("switch peer", "list", sw.peers.list, "localnode", sw.nodeInfo.ID())
+	sw.Logger.Debug("switch peer", "list", sw.peers.List(), "localnode", sw.nodeInfo.ID())
 	return nil
 }
@@ -1,10 +1,13 @@
 package core
 
 import (
+	"context"
 	"time"
 
 	cfg "CipherMachine/config"
 	"CipherMachine/p2p"
+	"CipherMachine/threshold"
+	"CipherMachine/tsslib/common"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/proxy"
@@ -36,6 +39,21 @@ type peers interface {
 	Peers() p2p.IPeerSet
 }
 
+type tssReactor interface {
+	Keygen(context.Context, threshold.KeygenRequest) *threshold.KeygenResult
+	Sign(context.Context, threshold.SignRequest) *threshold.SignResult
+	VerifyMessage(threshold.KeyID, []byte, threshold.HashAlg, common.SignatureData) error
+	Resharing(threshold.KeyID, []string, int) (chan *threshold.ResharingResult, error)
+	KeyInfo(threshold.KeyID) (*threshold.KeyInfo, error)
+	ListKeys() ([]*threshold.KeyInfo, error)
+	RetireKey(threshold.KeyID) error
+	DeleteKey(threshold.KeyID, string) error
+	ExportShare(threshold.KeyID, string, string) error
+	ImportShare(string, string) (threshold.KeyID, error)
+	PresignatureCount(threshold.KeyID) int
+	Rekey(threshold.StoreSecret, threshold.StoreSecret) error
+}
+
 //----------------------------------------------
 // These package level globals come with setters
 // that are expected to be called only once, on startup
@@ -47,6 +65,7 @@ var (
 	// interfaces defined in types and above
 	p2pPeers       peers
 	p2pTransport   transport
+	tssR           tssReactor
 
 	// objects
 	pubKey           crypto.PubKey
@@ -65,6 +84,10 @@ func SetP2PTransport(t transport) {
 	p2pTransport = t
 }
 
+func SetTssReactor(r tssReactor) {
+	tssR = r
+}
+
 func SetAddrBook(book p2p.AddrBook) {
 	addrBook = book
 }
@@ -10,12 +10,33 @@ import (
 // TODO: better system than "unsafe" prefix
 // NOTE: Amino is registered in rpc/core/types/codec.go.
 var Routes = map[string]*rpc.RPCFunc{
+	// tss API
+	"tss_keygen":         rpc.NewRPCFunc(TssKeygen, "key_id,key_type,curve,threshold"),
+	"tss_sign":           rpc.NewRPCFunc(TssSign, "key_id,msg,hash_alg,parties,path"),
+	"tss_reshare":        rpc.NewRPCFunc(TssReshare, "key_id,new_peers,new_threshold"),
+	"tss_verify":         rpc.NewRPCFunc(TssVerify, "key_id,msg,hash_alg,signature"),
+	"tss_keys":           rpc.NewRPCFunc(TssListKeys, ""),
+	"tss_key_info":       rpc.NewRPCFunc(TssKeyInfo, "key_id"),
+	"tss_retire_key":     rpc.NewRPCFunc(TssRetireKey, "key_id"),
+	"tss_session_status": rpc.NewRPCFunc(TssSessionStatus, "session_id"),
+	"tss_subscribe":      rpc.NewWSRPCFunc(TssSubscribe, "session_id"),
+	"tss_unsubscribe":    rpc.NewWSRPCFunc(TssUnsubscribe, "session_id"),
 }
 
 func AddUnsafeRoutes() {
 	// control API
 	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
 	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent")
+
+	// share backups, the passphrase is sent in the clear
+	Routes["tss_export_share"] = rpc.NewRPCFunc(UnsafeTssExportShare, "key_id,path,passphrase")
+	Routes["tss_import_share"] = rpc.NewRPCFunc(UnsafeTssImportShare, "path,passphrase")
+
+	// deletes a share for good, confirm is the public key of the key
+	Routes["tss_delete_key"] = rpc.NewRPCFunc(UnsafeTssDeleteKey, "key_id,confirm")
+
+	// the secret of the tss store, the passphrases are sent in the clear
+	Routes["tss_rekey"] = rpc.NewRPCFunc(UnsafeTssRekey, "old_passphrase,old_key_file,new_passphrase,new_key_file")
 }
 
 func UnsafeDialSeeds(ctx *rpctypes.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
@@ -0,0 +1,219 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+
+	tsstypes "CipherMachine/rpc/core/types"
+)
+
+// sessionRetention is how long a finished session started over rpc can still
+// be looked up.
+const sessionRetention = time.Hour
+
+// sessionSubscription pushes the end of a session, of every session if
+// sessionID is empty, to a websocket client or a gRPC stream.
+type sessionSubscription struct {
+	sessionID string
+	push      func(*tsstypes.ResultSession)
+}
+
+// rpcSessions tracks the keygen and signing sessions started over rpc and
+// the websocket clients subscribed to them.
+type rpcSessions struct {
+	mtx      sync.Mutex
+	sessions map[string]*tsstypes.ResultSession
+	// closed when the running session of the id ends
+	done map[string]chan struct{}
+	// subscriptions by the remote address of the websocket client, or the
+	// subscriber of SubscribeSessions
+	subs map[string][]sessionSubscription
+}
+
+var sessions = &rpcSessions{
+	sessions: make(map[string]*tsstypes.ResultSession),
+	done:     make(map[string]chan struct{}),
+	subs:     make(map[string][]sessionSubscription),
+}
+
+// start records a running session of typ with keyID and returns its id.
+func (r *rpcSessions) start(typ, keyID string) string {
+	bz := make([]byte, 16)
+	if _, err := rand.Read(bz); err != nil {
+		panic(err)
+	}
+	id := hex.EncodeToString(bz)
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	now := time.Now().UTC()
+	for sid, s := range r.sessions {
+		if s.Status != tsstypes.SessionRunning && now.Sub(s.Ended) > sessionRetention {
+			delete(r.sessions, sid)
+		}
+	}
+	r.sessions[id] = &tsstypes.ResultSession{
+		SessionID: id,
+		Type:      typ,
+		KeyID:     keyID,
+		Status:    tsstypes.SessionRunning,
+		Started:   now,
+	}
+	r.done[id] = make(chan struct{})
+	return id
+}
+
+// get returns a copy of the session of id.
+func (r *rpcSessions) get(id string) (*tsstypes.ResultSession, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	s, ok := r.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("no session %s", id)
+	}
+	res := *s
+	return &res, nil
+}
+
+// end records the outcome of the session of id, set by done, and pushes it
+// to the subscribers.
+func (r *rpcSessions) end(id string, done func(*tsstypes.ResultSession)) {
+	r.mtx.Lock()
+	s, ok := r.sessions[id]
+	if !ok {
+		r.mtx.Unlock()
+		return
+	}
+	done(s)
+	s.Ended = time.Now().UTC()
+	close(r.done[id])
+	delete(r.done, id)
+	res := *s
+	var subs []sessionSubscription
+	for _, clientSubs := range r.subs {
+		for _, sub := range clientSubs {
+			if sub.sessionID == "" || sub.sessionID == id {
+				subs = append(subs, sub)
+			}
+		}
+	}
+	r.mtx.Unlock()
+
+	for _, sub := range subs {
+		sub.push(&res)
+	}
+}
+
+// wait returns the session of id once it ends, or ctx.Err() if ctx is done
+// first.
+func (r *rpcSessions) wait(ctx context.Context, id string) (*tsstypes.ResultSession, error) {
+	r.mtx.Lock()
+	done, running := r.done[id]
+	r.mtx.Unlock()
+	if running {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return r.get(id)
+}
+
+// wsPush writes the end of a session to the websocket client conn, without
+// blocking the caller on a slow client.
+func wsPush(conn rpctypes.WSRPCConnection, eventID rpctypes.JSONRPCStringID) func(*tsstypes.ResultSession) {
+	return func(s *tsstypes.ResultSession) {
+		resp := rpctypes.NewRPCSuccessResponse(conn.Codec(), eventID, &tsstypes.ResultSessionEvent{Session: s})
+		go conn.WriteRPCResponse(resp)
+	}
+}
+
+func (r *rpcSessions) subscribe(subscriber, sessionID string, push func(*tsstypes.ResultSession)) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	var ended *tsstypes.ResultSession
+	if sessionID != "" {
+		s, ok := r.sessions[sessionID]
+		if !ok {
+			return fmt.Errorf("no session %s", sessionID)
+		}
+		if s.Status != tsstypes.SessionRunning {
+			res := *s
+			ended = &res
+		}
+	}
+	clientSubs, ok := r.subs[subscriber]
+	if !ok && len(r.subs) >= config.MaxSubscriptionClients {
+		return fmt.Errorf("max_subscription_clients %d reached", config.MaxSubscriptionClients)
+	}
+	if len(clientSubs) >= config.MaxSubscriptionsPerClient {
+		return fmt.Errorf("max_subscriptions_per_client %d reached", config.MaxSubscriptionsPerClient)
+	}
+	for _, sub := range clientSubs {
+		if sub.sessionID == sessionID {
+			return errors.New("already subscribed")
+		}
+	}
+	// a session that has already ended is pushed right away
+	if ended != nil {
+		push(ended)
+		return nil
+	}
+	r.subs[subscriber] = append(clientSubs, sessionSubscription{sessionID: sessionID, push: push})
+	return nil
+}
+
+func (r *rpcSessions) unsubscribe(addr, sessionID string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	clientSubs := r.subs[addr]
+	for i, sub := range clientSubs {
+		if sub.sessionID == sessionID {
+			clientSubs = append(clientSubs[:i], clientSubs[i+1:]...)
+			if len(clientSubs) == 0 {
+				delete(r.subs, addr)
+			} else {
+				r.subs[addr] = clientSubs
+			}
+			return nil
+		}
+	}
+	return errors.New("subscription not found")
+}
+
+// SubscribeSessions sends the end of the session of sessionID, of every
+// session started over rpc or gRPC if sessionID is empty, to out until
+// UnsubscribeAll(subscriber). An event is dropped if out is full.
+func SubscribeSessions(subscriber, sessionID string, out chan<- *tsstypes.ResultSession) error {
+	return sessions.subscribe(subscriber, sessionID, func(s *tsstypes.ResultSession) {
+		select {
+		case out <- s:
+		default:
+			logger.Error("Dropping session event", "subscriber", subscriber, "session", s.SessionID)
+		}
+	})
+}
+
+// UnsubscribeAll drops the session subscriptions of the websocket client at
+// remoteAddr, it is called when the client disconnects, or of the subscriber
+// of SubscribeSessions.
+func UnsubscribeAll(remoteAddr string) {
+	sessions.mtx.Lock()
+	defer sessions.mtx.Unlock()
+	delete(sessions.subs, remoteAddr)
+}
+
+// WaitSession returns the session of sessionID, started over rpc or gRPC,
+// once it ends. Unlike SubscribeSessions it takes no subscription, the
+// session keeps running if ctx is done first.
+func WaitSession(ctx context.Context, sessionID string) (*tsstypes.ResultSession, error) {
+	return sessions.wait(ctx, sessionID)
+}
@@ -0,0 +1,263 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+
+	tsstypes "CipherMachine/rpc/core/types"
+	"CipherMachine/threshold"
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/tss"
+)
+
+// TssKeygen starts the keygen of a new key of key_type on curve, stored under
+// key_id, with the persistent peers. An empty key_type is an ECDSA key, an
+// empty curve the default curve of the key type. It returns the running
+// session, tss_session_status and tss_subscribe tell when it ends.
+func TssKeygen(ctx *rpctypes.Context, keyID, keyType, curve string, t int) (*tsstypes.ResultSession, error) {
+	if keyID == "" {
+		return nil, errors.New("no key_id provided")
+	}
+	req := threshold.KeygenRequest{
+		KeyID:     threshold.KeyID(keyID),
+		KeyType:   threshold.KeyType(keyType),
+		Curve:     tss.CurveName(curve),
+		Threshold: t,
+	}
+	id := sessions.start(tsstypes.SessionKeygen, keyID)
+	logger.Info("TssKeygen", "key", keyID, "session", id)
+	go func() {
+		res := tssR.Keygen(context.Background(), req)
+		var pubKey cmn.HexBytes
+		if res.Err == nil {
+			// the key info has the encoding of the key type
+			if info, err := tssR.KeyInfo(req.KeyID); err == nil {
+				pubKey = info.PubKey
+			}
+		}
+		sessions.end(id, func(s *tsstypes.ResultSession) {
+			if res.Err != nil {
+				failSession(s, res.Err)
+				return
+			}
+			s.Status = tsstypes.SessionDone
+			s.PubKey = pubKey
+		})
+	}()
+	return sessions.get(id)
+}
+
+// TssSign starts signing msg with the key of key_id. With an empty hash_alg
+// or "none" msg is the digest an ECDSA key signs, or the message an EdDSA or
+// Schnorr key signs, else it is hashed with hash_alg first. parties
+// optionally names the signing quorum and path the BIP32 child key that
+// signs. It returns the running session like tss_keygen.
+func TssSign(ctx *rpctypes.Context, keyID string, msg cmn.HexBytes, hashAlg string, parties []string, path []uint32) (*tsstypes.ResultSession, error) {
+	if keyID == "" {
+		return nil, errors.New("no key_id provided")
+	}
+	req := threshold.SignRequest{
+		KeyID:   threshold.KeyID(keyID),
+		HashAlg: threshold.HashAlg(hashAlg),
+		Parties: parties,
+		Path:    path,
+	}
+	if req.HashAlg == "" {
+		req.HashAlg = threshold.HashNone
+	}
+	if req.HashAlg == threshold.HashNone {
+		req.Data = append([]byte{}, msg...)
+	} else {
+		digest, err := threshold.HashMessage(msg, req.HashAlg)
+		if err != nil {
+			return nil, err
+		}
+		req.Msg = new(big.Int).SetBytes(digest[:])
+	}
+	id := sessions.start(tsstypes.SessionSign, keyID)
+	logger.Info("TssSign", "key", keyID, "session", id)
+	go func() {
+		res := tssR.Sign(context.Background(), req)
+		sessions.end(id, func(s *tsstypes.ResultSession) {
+			if res.Err != nil {
+				failSession(s, res.Err)
+				return
+			}
+			s.Status = tsstypes.SessionDone
+			s.Signature = resultSignature(res.Signature, res.HashAlg)
+		})
+	}()
+	return sessions.get(id)
+}
+
+// TssReshare starts moving the key of key_id to the committee of new_peers
+// with new_threshold. It returns the running session like tss_keygen.
+func TssReshare(ctx *rpctypes.Context, keyID string, newPeers []string, newThreshold int) (*tsstypes.ResultSession, error) {
+	resCh, err := tssR.Resharing(threshold.KeyID(keyID), newPeers, newThreshold)
+	if err != nil {
+		return nil, err
+	}
+	id := sessions.start(tsstypes.SessionResharing, keyID)
+	logger.Info("TssReshare", "key", keyID, "session", id)
+	go func() {
+		res := <-resCh
+		sessions.end(id, func(s *tsstypes.ResultSession) {
+			if res.Err != nil {
+				failSession(s, res.Err)
+				return
+			}
+			s.Status = tsstypes.SessionDone
+		})
+	}()
+	return sessions.get(id)
+}
+
+// TssVerify checks signature, R||S as tss_sign returns it, of msg hashed
+// with hash_alg by the key of key_id.
+func TssVerify(ctx *rpctypes.Context, keyID string, msg cmn.HexBytes, hashAlg string, signature cmn.HexBytes) (*tsstypes.ResultVerify, error) {
+	if _, err := tssR.KeyInfo(threshold.KeyID(keyID)); err != nil {
+		return nil, err
+	}
+	if len(signature) != 64 {
+		return nil, errors.New("signature is not 64 bytes R||S")
+	}
+	alg := threshold.HashAlg(hashAlg)
+	if alg == "" {
+		alg = threshold.HashNone
+	}
+	sig := common.SignatureData{Signature: signature, R: signature[:32], S: signature[32:]}
+	if err := tssR.VerifyMessage(threshold.KeyID(keyID), msg, alg, sig); err != nil {
+		return &tsstypes.ResultVerify{Valid: false, Log: err.Error()}, nil
+	}
+	return &tsstypes.ResultVerify{Valid: true}, nil
+}
+
+// TssKeyInfo describes the key of key_id.
+func TssKeyInfo(ctx *rpctypes.Context, keyID string) (*tsstypes.ResultKeyInfo, error) {
+	info, err := tssR.KeyInfo(threshold.KeyID(keyID))
+	if err != nil {
+		return nil, err
+	}
+	return &tsstypes.ResultKeyInfo{Key: info, Presignatures: tssR.PresignatureCount(info.KeyID)}, nil
+}
+
+// TssListKeys describes the keys the node holds.
+func TssListKeys(ctx *rpctypes.Context) (*tsstypes.ResultKeys, error) {
+	keys, err := tssR.ListKeys()
+	if err != nil {
+		return nil, err
+	}
+	return &tsstypes.ResultKeys{Keys: keys}, nil
+}
+
+// TssRetireKey retires the key of key_id, the node refuses to sign with it
+// from now on. It returns the key info.
+func TssRetireKey(ctx *rpctypes.Context, keyID string) (*tsstypes.ResultKeyInfo, error) {
+	logger.Info("RetireKey", "key", keyID)
+	if err := tssR.RetireKey(threshold.KeyID(keyID)); err != nil {
+		return nil, err
+	}
+	return TssKeyInfo(ctx, keyID)
+}
+
+// TssSessionStatus returns the session of session_id, started by tss_keygen,
+// tss_sign or tss_reshare. Finished sessions are kept for an hour.
+func TssSessionStatus(ctx *rpctypes.Context, sessionID string) (*tsstypes.ResultSession, error) {
+	return sessions.get(sessionID)
+}
+
+// TssSubscribe pushes the end of the session of session_id to the websocket
+// client, of every session started over rpc if session_id is empty. The
+// events carry the request id with a "#event" suffix. A session that has
+// already ended is pushed right away.
+func TssSubscribe(ctx *rpctypes.Context, sessionID string) (*tsstypes.ResultSubscribe, error) {
+	eventID := rpctypes.JSONRPCStringID(fmt.Sprintf("%v#event", ctx.JSONReq.ID))
+	if err := sessions.subscribe(ctx.RemoteAddr(), sessionID, wsPush(ctx.WSConn, eventID)); err != nil {
+		return nil, err
+	}
+	logger.Info("TssSubscribe", "remote", ctx.RemoteAddr(), "session", sessionID)
+	return &tsstypes.ResultSubscribe{}, nil
+}
+
+// TssUnsubscribe drops the subscription of the websocket client to
+// session_id.
+func TssUnsubscribe(ctx *rpctypes.Context, sessionID string) (*tsstypes.ResultSubscribe, error) {
+	if err := sessions.unsubscribe(ctx.RemoteAddr(), sessionID); err != nil {
+		return nil, err
+	}
+	return &tsstypes.ResultSubscribe{}, nil
+}
+
+// UnsafeTssExportShare writes the share of key_id to a new backup file at
+// path on the node, encrypted with passphrase.
+func UnsafeTssExportShare(ctx *rpctypes.Context, keyID, path, passphrase string) (*tsstypes.ResultShareBackup, error) {
+	logger.Info("ExportShare", "key", keyID, "path", path)
+	if err := tssR.ExportShare(threshold.KeyID(keyID), path, passphrase); err != nil {
+		return nil, err
+	}
+	return &tsstypes.ResultShareBackup{KeyID: keyID, Path: path}, nil
+}
+
+// UnsafeTssImportShare restores the share in the backup file at path on the
+// node.
+func UnsafeTssImportShare(ctx *rpctypes.Context, path, passphrase string) (*tsstypes.ResultShareBackup, error) {
+	logger.Info("ImportShare", "path", path)
+	keyID, err := tssR.ImportShare(path, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &tsstypes.ResultShareBackup{KeyID: string(keyID), Path: path}, nil
+}
+
+// UnsafeTssDeleteKey deletes the share of the retired key of key_id for
+// good. confirm is the public key of the key in hex as tss_keys lists it. It
+// is refused unless the node enables the unsafe rpc methods, gRPC calls it
+// too.
+func UnsafeTssDeleteKey(ctx *rpctypes.Context, keyID, confirm string) (*tsstypes.ResultDeleteKey, error) {
+	if !config.Unsafe {
+		return nil, errors.New("deleting a key needs the unsafe rpc methods")
+	}
+	logger.Info("DeleteKey", "key", keyID)
+	if err := tssR.DeleteKey(threshold.KeyID(keyID), confirm); err != nil {
+		return nil, err
+	}
+	return &tsstypes.ResultDeleteKey{KeyID: keyID}, nil
+}
+
+// UnsafeTssRekey replaces the passphrase or key file of the tss store of the
+// node. Either a passphrase or a key file on the node is given for the old
+// and for the new secret, the shares are not rewritten.
+func UnsafeTssRekey(ctx *rpctypes.Context, oldPassphrase, oldKeyFile, newPassphrase, newKeyFile string) (*tsstypes.ResultRekey, error) {
+	logger.Info("Rekey", "keyFile", newKeyFile)
+	oldSecret := threshold.StoreSecret{Passphrase: oldPassphrase, KeyFile: oldKeyFile}
+	newSecret := threshold.StoreSecret{Passphrase: newPassphrase, KeyFile: newKeyFile}
+	if err := tssR.Rekey(oldSecret, newSecret); err != nil {
+		return nil, err
+	}
+	return &tsstypes.ResultRekey{KeyFile: newKeyFile}, nil
+}
+
+func failSession(s *tsstypes.ResultSession, err *tss.Error) {
+	s.Status = tsstypes.SessionFailed
+	s.Error = err.Error()
+	for _, culprit := range err.Culprits() {
+		s.Culprits = append(s.Culprits, culprit.Id)
+	}
+}
+
+// resultSignature returns sig with R and S padded to 32 bytes each.
+func resultSignature(sig *common.SignatureData, alg threshold.HashAlg) *tsstypes.ResultSignature {
+	bz := make([]byte, 64)
+	copy(bz[32-len(sig.R):32], sig.R)
+	copy(bz[64-len(sig.S):], sig.S)
+	res := &tsstypes.ResultSignature{Signature: bz, M: sig.M, HashAlg: string(alg)}
+	if len(sig.SignatureRecovery) > 0 {
+		res.Recovery = int(sig.SignatureRecovery[0])
+	}
+	return res
+}
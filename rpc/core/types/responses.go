@@ -0,0 +1,95 @@
+package coretypes
+
+import (
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"CipherMachine/threshold"
+)
+
+// Status of a session started over rpc.
+const (
+	SessionRunning = "running"
+	SessionDone    = "done"
+	SessionFailed  = "failed"
+)
+
+// Type of a session started over rpc.
+const (
+	SessionKeygen    = "keygen"
+	SessionSign      = "sign"
+	SessionResharing = "resharing"
+)
+
+// ResultSession describes a keygen, signing or resharing session started over
+// rpc. A finished keygen carries the public key, encoded as in the key info, a
+// finished signing the signature. A failed session carries the error and the
+// parties that caused it.
+type ResultSession struct {
+	SessionID string    `json:"session_id"`
+	Type      string    `json:"type"`
+	KeyID     string    `json:"key_id"`
+	Status    string    `json:"status"`
+	Started   time.Time `json:"started"`
+	Ended     time.Time `json:"ended"`
+
+	PubKey    cmn.HexBytes     `json:"pub_key,omitempty"`
+	Signature *ResultSignature `json:"signature,omitempty"`
+
+	Error    string   `json:"error,omitempty"`
+	Culprits []string `json:"culprits,omitempty"`
+}
+
+// ResultSignature is a signature made over rpc. Signature is R||S, each 32
+// bytes, the form tss_verify takes. M is the digest that was signed, or the
+// message an EdDSA or Schnorr key signed.
+type ResultSignature struct {
+	Signature cmn.HexBytes `json:"signature"`
+	Recovery  int          `json:"recovery"`
+	M         cmn.HexBytes `json:"m"`
+	HashAlg   string       `json:"hash_alg"`
+}
+
+// ResultSessionEvent is pushed to the websocket subscribers of a session when
+// it ends.
+type ResultSessionEvent struct {
+	Session *ResultSession `json:"session"`
+}
+
+// ResultVerify tells whether a signature is valid, Log says why it is not.
+type ResultVerify struct {
+	Valid bool   `json:"valid"`
+	Log   string `json:"log,omitempty"`
+}
+
+// ResultKeyInfo describes a key of the node and the presignatures it has left
+// for it.
+type ResultKeyInfo struct {
+	Key           *threshold.KeyInfo `json:"key"`
+	Presignatures int                `json:"presignatures"`
+}
+
+// ResultShareBackup names the key of a share backup file on the node.
+type ResultShareBackup struct {
+	KeyID string `json:"key_id"`
+	Path  string `json:"path"`
+}
+
+// ResultDeleteKey names the key whose share was deleted.
+type ResultDeleteKey struct {
+	KeyID string `json:"key_id"`
+}
+
+// ResultRekey tells how the tss store of the node is locked after a rekey.
+type ResultRekey struct {
+	KeyFile string `json:"key_file,omitempty"`
+}
+
+// ResultKeys lists the keys of the node.
+type ResultKeys struct {
+	Keys []*threshold.KeyInfo `json:"keys"`
+}
+
+// ResultSubscribe is the empty result of a subscription change.
+type ResultSubscribe struct{}
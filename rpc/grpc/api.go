@@ -0,0 +1,162 @@
+package coregrpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+
+	core "CipherMachine/rpc/core"
+	coretypes "CipherMachine/rpc/core/types"
+	"CipherMachine/threshold"
+)
+
+// sessionEventsBuffer is how many ended sessions a SessionEvents stream holds
+// for a slow client before dropping them.
+const sessionEventsBuffer = 100
+
+// tssAPI serves the calls with the handlers of the rpc, the sessions it
+// starts can be looked up and watched over both.
+type tssAPI struct {
+}
+
+func (tapi *tssAPI) Keygen(ctx context.Context, req *RequestKeygen) (*Session, error) {
+	s, err := core.TssKeygen(&rpctypes.Context{}, req.KeyId, req.KeyType, req.Curve, int(req.Threshold))
+	if err != nil {
+		return nil, err
+	}
+	return waitSession(ctx, s.SessionID)
+}
+
+func (tapi *tssAPI) Sign(ctx context.Context, req *RequestSign) (*Session, error) {
+	s, err := core.TssSign(&rpctypes.Context{}, req.KeyId, req.Msg, req.HashAlg, req.Parties, req.Path)
+	if err != nil {
+		return nil, err
+	}
+	return waitSession(ctx, s.SessionID)
+}
+
+func (tapi *tssAPI) Reshare(ctx context.Context, req *RequestReshare) (*Session, error) {
+	s, err := core.TssReshare(&rpctypes.Context{}, req.KeyId, req.NewPeers, int(req.NewThreshold))
+	if err != nil {
+		return nil, err
+	}
+	return waitSession(ctx, s.SessionID)
+}
+
+func (tapi *tssAPI) Verify(ctx context.Context, req *RequestVerify) (*ResponseVerify, error) {
+	res, err := core.TssVerify(&rpctypes.Context{}, req.KeyId, req.Msg, req.HashAlg, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseVerify{Valid: res.Valid, Log: res.Log}, nil
+}
+
+func (tapi *tssAPI) ListKeys(ctx context.Context, req *RequestListKeys) (*ResponseListKeys, error) {
+	res, err := core.TssListKeys(&rpctypes.Context{})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*KeyInfo, len(res.Keys))
+	for i, info := range res.Keys {
+		keys[i] = toKeyInfo(info)
+	}
+	return &ResponseListKeys{Keys: keys}, nil
+}
+
+func (tapi *tssAPI) RetireKey(ctx context.Context, req *RequestRetireKey) (*KeyInfo, error) {
+	res, err := core.TssRetireKey(&rpctypes.Context{}, req.KeyId)
+	if err != nil {
+		return nil, err
+	}
+	return toKeyInfo(res.Key), nil
+}
+
+func (tapi *tssAPI) DeleteKey(ctx context.Context, req *RequestDeleteKey) (*ResponseDeleteKey, error) {
+	res, err := core.UnsafeTssDeleteKey(&rpctypes.Context{}, req.KeyId, req.Confirm)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseDeleteKey{KeyId: res.KeyID}, nil
+}
+
+// SessionEvents streams the session of session_id once it ends and returns,
+// or every session as it ends until the client cancels if session_id is
+// empty.
+func (tapi *tssAPI) SessionEvents(req *RequestSessionEvents, stream TssService_SessionEventsServer) error {
+	subscriber := fmt.Sprintf("grpc#%p", stream)
+	out := make(chan *coretypes.ResultSession, sessionEventsBuffer)
+	if err := core.SubscribeSessions(subscriber, req.SessionId, out); err != nil {
+		return err
+	}
+	defer core.UnsubscribeAll(subscriber)
+	for {
+		select {
+		case s := <-out:
+			if err := stream.Send(toSession(s)); err != nil {
+				return err
+			}
+			if req.SessionId != "" {
+				return nil
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// waitSession returns the session of sessionID once it ends, the session
+// keeps running if ctx is done first.
+func waitSession(ctx context.Context, sessionID string) (*Session, error) {
+	s, err := core.WaitSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return toSession(s), nil
+}
+
+func toSession(s *coretypes.ResultSession) *Session {
+	res := &Session{
+		SessionId: s.SessionID,
+		Type:      s.Type,
+		KeyId:     s.KeyID,
+		Status:    s.Status,
+		Started:   unixNano(s.Started),
+		Ended:     unixNano(s.Ended),
+		PubKey:    s.PubKey,
+		Error:     s.Error,
+		Culprits:  s.Culprits,
+	}
+	if s.Signature != nil {
+		res.Signature = &Signature{
+			Signature: s.Signature.Signature,
+			Recovery:  int32(s.Signature.Recovery),
+			M:         s.Signature.M,
+			HashAlg:   s.Signature.HashAlg,
+		}
+	}
+	return res
+}
+
+func toKeyInfo(info *threshold.KeyInfo) *KeyInfo {
+	return &KeyInfo{
+		KeyId:     string(info.KeyID),
+		KeyType:   string(info.KeyType),
+		Curve:     string(info.Curve),
+		PubKey:    info.PubKey,
+		Threshold: int32(info.Threshold),
+		Committee: info.Committee,
+		Created:   unixNano(info.Created),
+		LastUsed:  unixNano(info.LastUsed),
+		Retired:   info.Retired,
+	}
+}
+
+// unixNano is 0 for the zero time.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
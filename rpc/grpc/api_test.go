@@ -0,0 +1,191 @@
+package coregrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	cfg "CipherMachine/config"
+	core "CipherMachine/rpc/core"
+	coretypes "CipherMachine/rpc/core/types"
+	"CipherMachine/threshold"
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/tss"
+)
+
+// fakeReactor ends every session after a short delay
+type fakeReactor struct{}
+
+func (fakeReactor) Keygen(ctx context.Context, req threshold.KeygenRequest) *threshold.KeygenResult {
+	time.Sleep(50 * time.Millisecond)
+	if req.Threshold < 1 {
+		return &threshold.KeygenResult{KeyID: req.KeyID, Err: tss.NewError(errors.New("bad threshold"), "keygen", 0, nil)}
+	}
+	return &threshold.KeygenResult{KeyID: req.KeyID}
+}
+
+func (fakeReactor) Sign(ctx context.Context, req threshold.SignRequest) *threshold.SignResult {
+	time.Sleep(50 * time.Millisecond)
+	sig := &common.SignatureData{R: []byte{1}, S: []byte{2}, SignatureRecovery: []byte{1}, M: req.Msg.Bytes()}
+	return &threshold.SignResult{KeyID: req.KeyID, Signature: sig, HashAlg: req.HashAlg}
+}
+
+func (fakeReactor) VerifyMessage(keyID threshold.KeyID, data []byte, alg threshold.HashAlg, sig common.SignatureData) error {
+	if sig.S[31] != 2 {
+		return errors.New("bad signature")
+	}
+	return nil
+}
+
+func (fakeReactor) Resharing(keyID threshold.KeyID, newPeers []string, newThreshold int) (chan *threshold.ResharingResult, error) {
+	ch := make(chan *threshold.ResharingResult, 1)
+	ch <- &threshold.ResharingResult{KeyID: keyID}
+	return ch, nil
+}
+
+func (fakeReactor) KeyInfo(keyID threshold.KeyID) (*threshold.KeyInfo, error) {
+	return &threshold.KeyInfo{KeyID: keyID, PubKey: []byte{2, 3}, Threshold: 1}, nil
+}
+
+func (r fakeReactor) ListKeys() ([]*threshold.KeyInfo, error) {
+	info, _ := r.KeyInfo("key")
+	return []*threshold.KeyInfo{info}, nil
+}
+
+func (fakeReactor) RetireKey(keyID threshold.KeyID) error { return nil }
+
+func (fakeReactor) DeleteKey(keyID threshold.KeyID, confirm string) error {
+	if confirm != "0203" {
+		return errors.New("bad confirmation")
+	}
+	return nil
+}
+
+func (fakeReactor) ExportShare(keyID threshold.KeyID, path string, passphrase string) error {
+	return nil
+}
+
+func (fakeReactor) ImportShare(path string, passphrase string) (threshold.KeyID, error) {
+	return "key", nil
+}
+
+func (fakeReactor) PresignatureCount(keyID threshold.KeyID) int { return 0 }
+
+func (fakeReactor) Rekey(oldSecret, newSecret threshold.StoreSecret) error { return nil }
+
+func startTestServer(t *testing.T) TssServiceClient {
+	core.SetTssReactor(fakeReactor{})
+	core.SetLogger(log.NewNopLogger())
+	core.SetConfig(*cfg.TestRPCConfig())
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go StartGRPCServer(ln)
+	t.Cleanup(func() { ln.Close() })
+	return StartGRPCClient("tcp://" + ln.Addr().String())
+}
+
+func TestTssService(t *testing.T) {
+	client := startTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// every session as it ends
+	events, err := client.SessionEvents(ctx, &RequestSessionEvents{})
+	require.NoError(t, err)
+
+	s, err := client.Keygen(ctx, &RequestKeygen{KeyId: "key", Threshold: 1})
+	require.NoError(t, err)
+	require.Equal(t, coretypes.SessionDone, s.Status)
+	require.Equal(t, []byte{2, 3}, s.PubKey)
+	require.NotZero(t, s.Ended)
+
+	s, err = client.Keygen(ctx, &RequestKeygen{KeyId: "bad"})
+	require.NoError(t, err)
+	require.Equal(t, coretypes.SessionFailed, s.Status)
+	require.NotEmpty(t, s.Error)
+
+	s, err = client.Sign(ctx, &RequestSign{KeyId: "key", Msg: []byte("hello"), HashAlg: "sha256"})
+	require.NoError(t, err)
+	require.Equal(t, coretypes.SessionDone, s.Status)
+	require.Len(t, s.Signature.Signature, 64)
+	require.Equal(t, byte(1), s.Signature.Signature[31])
+	require.Equal(t, int32(1), s.Signature.Recovery)
+
+	_, err = client.Sign(ctx, &RequestSign{KeyId: "key", Msg: []byte("hello"), HashAlg: "md5"})
+	require.Error(t, err)
+
+	v, err := client.Verify(ctx, &RequestVerify{KeyId: "key", Signature: s.Signature.Signature})
+	require.NoError(t, err)
+	require.True(t, v.Valid)
+
+	s, err = client.Reshare(ctx, &RequestReshare{KeyId: "key", NewPeers: []string{"a", "b"}, NewThreshold: 1})
+	require.NoError(t, err)
+	require.Equal(t, coretypes.SessionResharing, s.Type)
+	require.Equal(t, coretypes.SessionDone, s.Status)
+
+	keys, err := client.ListKeys(ctx, &RequestListKeys{})
+	require.NoError(t, err)
+	require.Len(t, keys.Keys, 1)
+	require.Equal(t, "key", keys.Keys[0].KeyId)
+
+	info, err := client.RetireKey(ctx, &RequestRetireKey{KeyId: "key"})
+	require.NoError(t, err)
+	require.Equal(t, "key", info.KeyId)
+
+	// deleting a key needs the unsafe rpc methods and a confirmation
+	config := cfg.TestRPCConfig()
+	config.Unsafe = false
+	core.SetConfig(*config)
+	_, err = client.DeleteKey(ctx, &RequestDeleteKey{KeyId: "key", Confirm: "0203"})
+	require.Error(t, err)
+	core.SetConfig(*cfg.TestRPCConfig())
+	_, err = client.DeleteKey(ctx, &RequestDeleteKey{KeyId: "key"})
+	require.Error(t, err)
+	deleted, err := client.DeleteKey(ctx, &RequestDeleteKey{KeyId: "key", Confirm: "0203"})
+	require.NoError(t, err)
+	require.Equal(t, "key", deleted.KeyId)
+
+	for _, typ := range []string{coretypes.SessionKeygen, coretypes.SessionKeygen, coretypes.SessionSign, coretypes.SessionResharing} {
+		ev, err := events.Recv()
+		require.NoError(t, err)
+		require.Equal(t, typ, ev.Type)
+	}
+
+	// a session that has ended is streamed right away
+	one, err := client.SessionEvents(ctx, &RequestSessionEvents{SessionId: s.SessionId})
+	require.NoError(t, err)
+	ev, err := one.Recv()
+	require.NoError(t, err)
+	require.Equal(t, s.SessionId, ev.SessionId)
+}
+
+// the unary calls wait on their sessions without a subscription, so more of
+// them than max_subscription_clients run at once
+func TestConcurrentCalls(t *testing.T) {
+	client := startTestServer(t)
+	config := cfg.TestRPCConfig()
+	config.MaxSubscriptionClients = 1
+	core.SetConfig(*config)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	n := 5
+	errCh := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			s, err := client.Sign(ctx, &RequestSign{KeyId: "key", Msg: []byte("hello"), HashAlg: "sha256"})
+			if err == nil && s.Status != coretypes.SessionDone {
+				err = errors.New(s.Error)
+			}
+			errCh <- err
+		}()
+	}
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errCh)
+	}
+}
@@ -0,0 +1,33 @@
+package coregrpc
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+// StartGRPCServer starts a new gRPC TssServiceServer using the given
+// net.Listener.
+// NOTE: This function blocks - you may want to call it in a go-routine.
+func StartGRPCServer(ln net.Listener) error {
+	grpcServer := grpc.NewServer()
+	RegisterTssServiceServer(grpcServer, &tssAPI{})
+	return grpcServer.Serve(ln)
+}
+
+// StartGRPCClient dials the gRPC server using protoAddr and returns a new
+// TssServiceClient.
+func StartGRPCClient(protoAddr string) TssServiceClient {
+	conn, err := grpc.Dial(protoAddr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+	if err != nil {
+		panic(err)
+	}
+	return NewTssServiceClient(conn)
+}
+
+func dialerFunc(ctx context.Context, addr string) (net.Conn, error) {
+	return cmn.Connect(addr)
+}
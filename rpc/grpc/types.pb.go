@@ -0,0 +1,1300 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: rpc/grpc/types.proto
+
+package coregrpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type RequestKeygen struct {
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// ecdsa, eddsa or schnorr, ecdsa if empty
+	KeyType string `protobuf:"bytes,2,opt,name=key_type,json=keyType,proto3" json:"key_type,omitempty"`
+	// the default curve of the key type if empty
+	Curve                string   `protobuf:"bytes,3,opt,name=curve,proto3" json:"curve,omitempty"`
+	Threshold            int32    `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestKeygen) Reset()         { *m = RequestKeygen{} }
+func (m *RequestKeygen) String() string { return proto.CompactTextString(m) }
+func (*RequestKeygen) ProtoMessage()    {}
+func (*RequestKeygen) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{0}
+}
+
+func (m *RequestKeygen) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestKeygen.Unmarshal(m, b)
+}
+func (m *RequestKeygen) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestKeygen.Marshal(b, m, deterministic)
+}
+func (m *RequestKeygen) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestKeygen.Merge(m, src)
+}
+func (m *RequestKeygen) XXX_Size() int {
+	return xxx_messageInfo_RequestKeygen.Size(m)
+}
+func (m *RequestKeygen) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestKeygen.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestKeygen proto.InternalMessageInfo
+
+func (m *RequestKeygen) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *RequestKeygen) GetKeyType() string {
+	if m != nil {
+		return m.KeyType
+	}
+	return ""
+}
+
+func (m *RequestKeygen) GetCurve() string {
+	if m != nil {
+		return m.Curve
+	}
+	return ""
+}
+
+func (m *RequestKeygen) GetThreshold() int32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+type RequestSign struct {
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// the digest an ECDSA key signs, or the message an EdDSA or Schnorr key
+	// signs, if hash_alg is empty or none, else hashed with hash_alg first
+	Msg []byte `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	// none, sha256, sha256d or keccak256
+	HashAlg string `protobuf:"bytes,3,opt,name=hash_alg,json=hashAlg,proto3" json:"hash_alg,omitempty"`
+	// node ids of the signing quorum, chosen by the node if empty
+	Parties []string `protobuf:"bytes,4,rep,name=parties,proto3" json:"parties,omitempty"`
+	// BIP32 path of the child key that signs
+	Path                 []uint32 `protobuf:"varint,5,rep,packed,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestSign) Reset()         { *m = RequestSign{} }
+func (m *RequestSign) String() string { return proto.CompactTextString(m) }
+func (*RequestSign) ProtoMessage()    {}
+func (*RequestSign) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{1}
+}
+
+func (m *RequestSign) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestSign.Unmarshal(m, b)
+}
+func (m *RequestSign) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestSign.Marshal(b, m, deterministic)
+}
+func (m *RequestSign) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestSign.Merge(m, src)
+}
+func (m *RequestSign) XXX_Size() int {
+	return xxx_messageInfo_RequestSign.Size(m)
+}
+func (m *RequestSign) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestSign.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestSign proto.InternalMessageInfo
+
+func (m *RequestSign) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *RequestSign) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *RequestSign) GetHashAlg() string {
+	if m != nil {
+		return m.HashAlg
+	}
+	return ""
+}
+
+func (m *RequestSign) GetParties() []string {
+	if m != nil {
+		return m.Parties
+	}
+	return nil
+}
+
+func (m *RequestSign) GetPath() []uint32 {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+type RequestReshare struct {
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// node ids of the new committee
+	NewPeers             []string `protobuf:"bytes,2,rep,name=new_peers,json=newPeers,proto3" json:"new_peers,omitempty"`
+	NewThreshold         int32    `protobuf:"varint,3,opt,name=new_threshold,json=newThreshold,proto3" json:"new_threshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestReshare) Reset()         { *m = RequestReshare{} }
+func (m *RequestReshare) String() string { return proto.CompactTextString(m) }
+func (*RequestReshare) ProtoMessage()    {}
+func (*RequestReshare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{2}
+}
+
+func (m *RequestReshare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestReshare.Unmarshal(m, b)
+}
+func (m *RequestReshare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestReshare.Marshal(b, m, deterministic)
+}
+func (m *RequestReshare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestReshare.Merge(m, src)
+}
+func (m *RequestReshare) XXX_Size() int {
+	return xxx_messageInfo_RequestReshare.Size(m)
+}
+func (m *RequestReshare) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestReshare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestReshare proto.InternalMessageInfo
+
+func (m *RequestReshare) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *RequestReshare) GetNewPeers() []string {
+	if m != nil {
+		return m.NewPeers
+	}
+	return nil
+}
+
+func (m *RequestReshare) GetNewThreshold() int32 {
+	if m != nil {
+		return m.NewThreshold
+	}
+	return 0
+}
+
+type RequestVerify struct {
+	KeyId   string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Msg     []byte `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	HashAlg string `protobuf:"bytes,3,opt,name=hash_alg,json=hashAlg,proto3" json:"hash_alg,omitempty"`
+	// R||S, 32 bytes each
+	Signature            []byte   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestVerify) Reset()         { *m = RequestVerify{} }
+func (m *RequestVerify) String() string { return proto.CompactTextString(m) }
+func (*RequestVerify) ProtoMessage()    {}
+func (*RequestVerify) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{3}
+}
+
+func (m *RequestVerify) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestVerify.Unmarshal(m, b)
+}
+func (m *RequestVerify) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestVerify.Marshal(b, m, deterministic)
+}
+func (m *RequestVerify) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestVerify.Merge(m, src)
+}
+func (m *RequestVerify) XXX_Size() int {
+	return xxx_messageInfo_RequestVerify.Size(m)
+}
+func (m *RequestVerify) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestVerify.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestVerify proto.InternalMessageInfo
+
+func (m *RequestVerify) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *RequestVerify) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *RequestVerify) GetHashAlg() string {
+	if m != nil {
+		return m.HashAlg
+	}
+	return ""
+}
+
+func (m *RequestVerify) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type RequestListKeys struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestListKeys) Reset()         { *m = RequestListKeys{} }
+func (m *RequestListKeys) String() string { return proto.CompactTextString(m) }
+func (*RequestListKeys) ProtoMessage()    {}
+func (*RequestListKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{4}
+}
+
+func (m *RequestListKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestListKeys.Unmarshal(m, b)
+}
+func (m *RequestListKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestListKeys.Marshal(b, m, deterministic)
+}
+func (m *RequestListKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestListKeys.Merge(m, src)
+}
+func (m *RequestListKeys) XXX_Size() int {
+	return xxx_messageInfo_RequestListKeys.Size(m)
+}
+func (m *RequestListKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestListKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestListKeys proto.InternalMessageInfo
+
+type RequestRetireKey struct {
+	KeyId                string   `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestRetireKey) Reset()         { *m = RequestRetireKey{} }
+func (m *RequestRetireKey) String() string { return proto.CompactTextString(m) }
+func (*RequestRetireKey) ProtoMessage()    {}
+func (*RequestRetireKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{5}
+}
+
+func (m *RequestRetireKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestRetireKey.Unmarshal(m, b)
+}
+func (m *RequestRetireKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestRetireKey.Marshal(b, m, deterministic)
+}
+func (m *RequestRetireKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestRetireKey.Merge(m, src)
+}
+func (m *RequestRetireKey) XXX_Size() int {
+	return xxx_messageInfo_RequestRetireKey.Size(m)
+}
+func (m *RequestRetireKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestRetireKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestRetireKey proto.InternalMessageInfo
+
+func (m *RequestRetireKey) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+type RequestDeleteKey struct {
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// public key of the retired key in hex as listed, confirms the deletion
+	Confirm              string   `protobuf:"bytes,2,opt,name=confirm,proto3" json:"confirm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestDeleteKey) Reset()         { *m = RequestDeleteKey{} }
+func (m *RequestDeleteKey) String() string { return proto.CompactTextString(m) }
+func (*RequestDeleteKey) ProtoMessage()    {}
+func (*RequestDeleteKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{6}
+}
+
+func (m *RequestDeleteKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestDeleteKey.Unmarshal(m, b)
+}
+func (m *RequestDeleteKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestDeleteKey.Marshal(b, m, deterministic)
+}
+func (m *RequestDeleteKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestDeleteKey.Merge(m, src)
+}
+func (m *RequestDeleteKey) XXX_Size() int {
+	return xxx_messageInfo_RequestDeleteKey.Size(m)
+}
+func (m *RequestDeleteKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestDeleteKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestDeleteKey proto.InternalMessageInfo
+
+func (m *RequestDeleteKey) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *RequestDeleteKey) GetConfirm() string {
+	if m != nil {
+		return m.Confirm
+	}
+	return ""
+}
+
+type RequestSessionEvents struct {
+	// the session to watch, every session if empty
+	SessionId            string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestSessionEvents) Reset()         { *m = RequestSessionEvents{} }
+func (m *RequestSessionEvents) String() string { return proto.CompactTextString(m) }
+func (*RequestSessionEvents) ProtoMessage()    {}
+func (*RequestSessionEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{7}
+}
+
+func (m *RequestSessionEvents) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestSessionEvents.Unmarshal(m, b)
+}
+func (m *RequestSessionEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestSessionEvents.Marshal(b, m, deterministic)
+}
+func (m *RequestSessionEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestSessionEvents.Merge(m, src)
+}
+func (m *RequestSessionEvents) XXX_Size() int {
+	return xxx_messageInfo_RequestSessionEvents.Size(m)
+}
+func (m *RequestSessionEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestSessionEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestSessionEvents proto.InternalMessageInfo
+
+func (m *RequestSessionEvents) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+type Signature struct {
+	// R||S, 32 bytes each
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	Recovery  int32  `protobuf:"varint,2,opt,name=recovery,proto3" json:"recovery,omitempty"`
+	// the signed digest or message
+	M                    []byte   `protobuf:"bytes,3,opt,name=m,proto3" json:"m,omitempty"`
+	HashAlg              string   `protobuf:"bytes,4,opt,name=hash_alg,json=hashAlg,proto3" json:"hash_alg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Signature) Reset()         { *m = Signature{} }
+func (m *Signature) String() string { return proto.CompactTextString(m) }
+func (*Signature) ProtoMessage()    {}
+func (*Signature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{8}
+}
+
+func (m *Signature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Signature.Unmarshal(m, b)
+}
+func (m *Signature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Signature.Marshal(b, m, deterministic)
+}
+func (m *Signature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Signature.Merge(m, src)
+}
+func (m *Signature) XXX_Size() int {
+	return xxx_messageInfo_Signature.Size(m)
+}
+func (m *Signature) XXX_DiscardUnknown() {
+	xxx_messageInfo_Signature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Signature proto.InternalMessageInfo
+
+func (m *Signature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Signature) GetRecovery() int32 {
+	if m != nil {
+		return m.Recovery
+	}
+	return 0
+}
+
+func (m *Signature) GetM() []byte {
+	if m != nil {
+		return m.M
+	}
+	return nil
+}
+
+func (m *Signature) GetHashAlg() string {
+	if m != nil {
+		return m.HashAlg
+	}
+	return ""
+}
+
+// Session describes a keygen, signing or resharing session started over rpc
+// or gRPC.
+type Session struct {
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// keygen, sign or resharing
+	Type  string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	KeyId string `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// running, done or failed
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// unix time in nanoseconds
+	Started int64 `protobuf:"varint,5,opt,name=started,proto3" json:"started,omitempty"`
+	Ended   int64 `protobuf:"varint,6,opt,name=ended,proto3" json:"ended,omitempty"`
+	// public key of a finished keygen, encoded as in the key info
+	PubKey []byte `protobuf:"bytes,7,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	// signature of a finished signing
+	Signature *Signature `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	// error of a failed session and the node ids of the parties that caused it
+	Error                string   `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	Culprits             []string `protobuf:"bytes,10,rep,name=culprits,proto3" json:"culprits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Session) Reset()         { *m = Session{} }
+func (m *Session) String() string { return proto.CompactTextString(m) }
+func (*Session) ProtoMessage()    {}
+func (*Session) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{9}
+}
+
+func (m *Session) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Session.Unmarshal(m, b)
+}
+func (m *Session) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Session.Marshal(b, m, deterministic)
+}
+func (m *Session) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Session.Merge(m, src)
+}
+func (m *Session) XXX_Size() int {
+	return xxx_messageInfo_Session.Size(m)
+}
+func (m *Session) XXX_DiscardUnknown() {
+	xxx_messageInfo_Session.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Session proto.InternalMessageInfo
+
+func (m *Session) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *Session) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Session) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *Session) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Session) GetStarted() int64 {
+	if m != nil {
+		return m.Started
+	}
+	return 0
+}
+
+func (m *Session) GetEnded() int64 {
+	if m != nil {
+		return m.Ended
+	}
+	return 0
+}
+
+func (m *Session) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *Session) GetSignature() *Signature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Session) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Session) GetCulprits() []string {
+	if m != nil {
+		return m.Culprits
+	}
+	return nil
+}
+
+type KeyInfo struct {
+	KeyId   string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	KeyType string `protobuf:"bytes,2,opt,name=key_type,json=keyType,proto3" json:"key_type,omitempty"`
+	Curve   string `protobuf:"bytes,3,opt,name=curve,proto3" json:"curve,omitempty"`
+	// compressed public key, the RFC 8032 encoding for an EdDSA key
+	PubKey    []byte   `protobuf:"bytes,4,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Threshold int32    `protobuf:"varint,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Committee []string `protobuf:"bytes,6,rep,name=committee,proto3" json:"committee,omitempty"`
+	// unix time in nanoseconds, 0 if unknown
+	Created              int64    `protobuf:"varint,7,opt,name=created,proto3" json:"created,omitempty"`
+	LastUsed             int64    `protobuf:"varint,8,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`
+	Retired              bool     `protobuf:"varint,9,opt,name=retired,proto3" json:"retired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyInfo) Reset()         { *m = KeyInfo{} }
+func (m *KeyInfo) String() string { return proto.CompactTextString(m) }
+func (*KeyInfo) ProtoMessage()    {}
+func (*KeyInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{10}
+}
+
+func (m *KeyInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyInfo.Unmarshal(m, b)
+}
+func (m *KeyInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyInfo.Marshal(b, m, deterministic)
+}
+func (m *KeyInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyInfo.Merge(m, src)
+}
+func (m *KeyInfo) XXX_Size() int {
+	return xxx_messageInfo_KeyInfo.Size(m)
+}
+func (m *KeyInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyInfo proto.InternalMessageInfo
+
+func (m *KeyInfo) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *KeyInfo) GetKeyType() string {
+	if m != nil {
+		return m.KeyType
+	}
+	return ""
+}
+
+func (m *KeyInfo) GetCurve() string {
+	if m != nil {
+		return m.Curve
+	}
+	return ""
+}
+
+func (m *KeyInfo) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *KeyInfo) GetThreshold() int32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *KeyInfo) GetCommittee() []string {
+	if m != nil {
+		return m.Committee
+	}
+	return nil
+}
+
+func (m *KeyInfo) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *KeyInfo) GetLastUsed() int64 {
+	if m != nil {
+		return m.LastUsed
+	}
+	return 0
+}
+
+func (m *KeyInfo) GetRetired() bool {
+	if m != nil {
+		return m.Retired
+	}
+	return false
+}
+
+type ResponseVerify struct {
+	Valid                bool     `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Log                  string   `protobuf:"bytes,2,opt,name=log,proto3" json:"log,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResponseVerify) Reset()         { *m = ResponseVerify{} }
+func (m *ResponseVerify) String() string { return proto.CompactTextString(m) }
+func (*ResponseVerify) ProtoMessage()    {}
+func (*ResponseVerify) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{11}
+}
+
+func (m *ResponseVerify) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResponseVerify.Unmarshal(m, b)
+}
+func (m *ResponseVerify) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResponseVerify.Marshal(b, m, deterministic)
+}
+func (m *ResponseVerify) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseVerify.Merge(m, src)
+}
+func (m *ResponseVerify) XXX_Size() int {
+	return xxx_messageInfo_ResponseVerify.Size(m)
+}
+func (m *ResponseVerify) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseVerify.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseVerify proto.InternalMessageInfo
+
+func (m *ResponseVerify) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *ResponseVerify) GetLog() string {
+	if m != nil {
+		return m.Log
+	}
+	return ""
+}
+
+type ResponseListKeys struct {
+	Keys                 []*KeyInfo `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ResponseListKeys) Reset()         { *m = ResponseListKeys{} }
+func (m *ResponseListKeys) String() string { return proto.CompactTextString(m) }
+func (*ResponseListKeys) ProtoMessage()    {}
+func (*ResponseListKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{12}
+}
+
+func (m *ResponseListKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResponseListKeys.Unmarshal(m, b)
+}
+func (m *ResponseListKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResponseListKeys.Marshal(b, m, deterministic)
+}
+func (m *ResponseListKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseListKeys.Merge(m, src)
+}
+func (m *ResponseListKeys) XXX_Size() int {
+	return xxx_messageInfo_ResponseListKeys.Size(m)
+}
+func (m *ResponseListKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseListKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseListKeys proto.InternalMessageInfo
+
+func (m *ResponseListKeys) GetKeys() []*KeyInfo {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+type ResponseDeleteKey struct {
+	KeyId                string   `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResponseDeleteKey) Reset()         { *m = ResponseDeleteKey{} }
+func (m *ResponseDeleteKey) String() string { return proto.CompactTextString(m) }
+func (*ResponseDeleteKey) ProtoMessage()    {}
+func (*ResponseDeleteKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{13}
+}
+
+func (m *ResponseDeleteKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResponseDeleteKey.Unmarshal(m, b)
+}
+func (m *ResponseDeleteKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResponseDeleteKey.Marshal(b, m, deterministic)
+}
+func (m *ResponseDeleteKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseDeleteKey.Merge(m, src)
+}
+func (m *ResponseDeleteKey) XXX_Size() int {
+	return xxx_messageInfo_ResponseDeleteKey.Size(m)
+}
+func (m *ResponseDeleteKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseDeleteKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseDeleteKey proto.InternalMessageInfo
+
+func (m *ResponseDeleteKey) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*RequestKeygen)(nil), "coregrpc.RequestKeygen")
+	proto.RegisterType((*RequestSign)(nil), "coregrpc.RequestSign")
+	proto.RegisterType((*RequestReshare)(nil), "coregrpc.RequestReshare")
+	proto.RegisterType((*RequestVerify)(nil), "coregrpc.RequestVerify")
+	proto.RegisterType((*RequestListKeys)(nil), "coregrpc.RequestListKeys")
+	proto.RegisterType((*RequestRetireKey)(nil), "coregrpc.RequestRetireKey")
+	proto.RegisterType((*RequestDeleteKey)(nil), "coregrpc.RequestDeleteKey")
+	proto.RegisterType((*RequestSessionEvents)(nil), "coregrpc.RequestSessionEvents")
+	proto.RegisterType((*Signature)(nil), "coregrpc.Signature")
+	proto.RegisterType((*Session)(nil), "coregrpc.Session")
+	proto.RegisterType((*KeyInfo)(nil), "coregrpc.KeyInfo")
+	proto.RegisterType((*ResponseVerify)(nil), "coregrpc.ResponseVerify")
+	proto.RegisterType((*ResponseListKeys)(nil), "coregrpc.ResponseListKeys")
+	proto.RegisterType((*ResponseDeleteKey)(nil), "coregrpc.ResponseDeleteKey")
+}
+
+func init() { proto.RegisterFile("rpc/grpc/types.proto", fileDescriptor_15f63baabf91876a) }
+
+var fileDescriptor_15f63baabf91876a = []byte{
+	// 815 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x4d, 0x6f, 0xe4, 0x44,
+	0x10, 0x95, 0xe3, 0x99, 0xb1, 0x5d, 0x99, 0x40, 0xd2, 0x04, 0xb6, 0x77, 0x76, 0x81, 0xc1, 0x08,
+	0x69, 0xe0, 0x90, 0x5d, 0xc2, 0x87, 0x80, 0x3d, 0x2d, 0x1b, 0x0e, 0x51, 0x40, 0x42, 0x4e, 0xe0,
+	0xc0, 0x65, 0xe4, 0xd8, 0x15, 0xdb, 0x1a, 0x8f, 0xed, 0xed, 0x6e, 0x4f, 0xe4, 0x13, 0xbf, 0x83,
+	0xff, 0x89, 0xc4, 0x15, 0x75, 0xbb, 0xfd, 0xb5, 0x4e, 0x76, 0x2f, 0xb9, 0x8c, 0xfa, 0x55, 0xb9,
+	0xba, 0xea, 0xbd, 0xae, 0xaa, 0x81, 0x63, 0x56, 0x04, 0xcf, 0x22, 0xf9, 0x23, 0xaa, 0x02, 0xf9,
+	0x49, 0xc1, 0x72, 0x91, 0x13, 0x3b, 0xc8, 0x19, 0x4a, 0xab, 0x5b, 0xc2, 0x81, 0x87, 0xaf, 0x4b,
+	0xe4, 0xe2, 0x02, 0xab, 0x08, 0x33, 0xf2, 0x21, 0xcc, 0x36, 0x58, 0xad, 0x93, 0x90, 0x1a, 0x4b,
+	0x63, 0xe5, 0x78, 0xd3, 0x0d, 0x56, 0xe7, 0x21, 0x79, 0x0c, 0xb6, 0x34, 0xcb, 0x4b, 0xe8, 0x9e,
+	0x72, 0x58, 0x1b, 0xac, 0xae, 0xaa, 0x02, 0xc9, 0x31, 0x4c, 0x83, 0x92, 0xed, 0x90, 0x9a, 0x75,
+	0x80, 0x02, 0xe4, 0x29, 0x38, 0x22, 0x66, 0xc8, 0xe3, 0x3c, 0x0d, 0xe9, 0x64, 0x69, 0xac, 0xa6,
+	0x5e, 0x67, 0x70, 0xff, 0x86, 0x7d, 0x9d, 0xf6, 0x32, 0x89, 0xee, 0x4d, 0x7a, 0x08, 0xe6, 0x96,
+	0x47, 0x2a, 0xdf, 0xdc, 0x93, 0x47, 0x59, 0x46, 0xec, 0xf3, 0x78, 0xed, 0xa7, 0x91, 0x4e, 0x67,
+	0x49, 0xfc, 0x32, 0x8d, 0x08, 0x05, 0xab, 0xf0, 0x99, 0x48, 0x90, 0xd3, 0xc9, 0xd2, 0x94, 0x1e,
+	0x0d, 0x09, 0x81, 0x49, 0xe1, 0x8b, 0x98, 0x4e, 0x97, 0xe6, 0xea, 0xc0, 0x53, 0x67, 0x37, 0x81,
+	0xf7, 0x74, 0x01, 0x1e, 0xf2, 0xd8, 0x67, 0x78, 0x5f, 0x0d, 0x4f, 0xc0, 0xc9, 0xf0, 0x76, 0x5d,
+	0x20, 0x32, 0x4e, 0xf7, 0xd4, 0xc5, 0x76, 0x86, 0xb7, 0xbf, 0x4b, 0x4c, 0x3e, 0x87, 0x03, 0xe9,
+	0xec, 0x88, 0x9a, 0x8a, 0xe8, 0x3c, 0xc3, 0xdb, 0xab, 0x96, 0xeb, 0xeb, 0x56, 0xe2, 0x3f, 0x91,
+	0x25, 0x37, 0xd5, 0x83, 0xb0, 0x7d, 0x0a, 0x0e, 0x4f, 0xa2, 0xcc, 0x17, 0x25, 0x43, 0x25, 0xef,
+	0xdc, 0xeb, 0x0c, 0xee, 0x11, 0xbc, 0xaf, 0x53, 0xfe, 0x9a, 0xa8, 0x97, 0xe5, 0xee, 0x97, 0x70,
+	0xd8, 0x12, 0x16, 0x09, 0xc3, 0x0b, 0xbc, 0xaf, 0x10, 0xf7, 0x55, 0xfb, 0xe9, 0x19, 0xa6, 0x28,
+	0xde, 0xf2, 0xa9, 0x14, 0x3d, 0xc8, 0xb3, 0x9b, 0x84, 0x6d, 0x9b, 0xae, 0xd0, 0xd0, 0xfd, 0x0e,
+	0x8e, 0x9b, 0x17, 0x46, 0xce, 0x93, 0x3c, 0xfb, 0x65, 0x87, 0x99, 0xe0, 0xe4, 0x63, 0x00, 0x5e,
+	0x1b, 0xba, 0xcb, 0x1c, 0x6d, 0x39, 0x0f, 0xdd, 0x0c, 0x9c, 0xcb, 0x86, 0xc6, 0x90, 0xa4, 0xf1,
+	0x06, 0x49, 0xb2, 0x00, 0x9b, 0x61, 0x90, 0xef, 0x90, 0x55, 0x2a, 0xf9, 0xd4, 0x6b, 0x31, 0x99,
+	0x83, 0xb1, 0x55, 0x92, 0xcd, 0x3d, 0x63, 0x3b, 0xd0, 0x71, 0x32, 0xd0, 0xd1, 0xfd, 0x67, 0x0f,
+	0x2c, 0x5d, 0xe0, 0x3b, 0x4a, 0x93, 0x6d, 0xd4, 0x6b, 0x7f, 0x75, 0xee, 0xc9, 0x62, 0xf6, 0x65,
+	0xf9, 0x08, 0x66, 0x5c, 0xf8, 0xa2, 0xe4, 0x3a, 0x9d, 0x46, 0x52, 0x2e, 0x2e, 0x7c, 0x26, 0x30,
+	0xa4, 0xd3, 0xa5, 0xb1, 0x32, 0xbd, 0x06, 0xca, 0x21, 0xc2, 0x2c, 0xc4, 0x90, 0xce, 0x94, 0xbd,
+	0x06, 0xe4, 0x11, 0x58, 0x45, 0x79, 0xbd, 0xde, 0x60, 0x45, 0x2d, 0x45, 0x66, 0x56, 0x94, 0xd7,
+	0xf2, 0x39, 0xbe, 0xee, 0x2b, 0x63, 0x2f, 0x8d, 0xd5, 0xfe, 0xe9, 0x07, 0x27, 0xcd, 0x50, 0x9f,
+	0xb4, 0x0a, 0xf6, 0xe5, 0x92, 0x19, 0x18, 0xcb, 0x19, 0x75, 0xea, 0x4a, 0x15, 0x90, 0x22, 0x06,
+	0x65, 0x5a, 0xb0, 0x44, 0x70, 0x0a, 0x75, 0x77, 0x37, 0xd8, 0xfd, 0xcf, 0x00, 0xeb, 0x02, 0xab,
+	0xf3, 0xec, 0x26, 0x7f, 0xb0, 0xb5, 0xd0, 0x63, 0x34, 0x19, 0x30, 0x1a, 0xec, 0x8b, 0xe9, 0x1b,
+	0xfb, 0x42, 0x7a, 0x83, 0x7c, 0xbb, 0x4d, 0x84, 0x40, 0xa4, 0x33, 0x55, 0x67, 0x67, 0x50, 0x5d,
+	0xc8, 0xd0, 0x97, 0xb2, 0x5a, 0xb5, 0xac, 0x1a, 0xca, 0xe9, 0x4d, 0x7d, 0x2e, 0xd6, 0x25, 0xc7,
+	0x50, 0xe9, 0x64, 0x7a, 0xb6, 0x34, 0xfc, 0xc1, 0x51, 0x35, 0x2f, 0x53, 0xb3, 0x10, 0x2a, 0x4d,
+	0x6c, 0xaf, 0x81, 0xee, 0x0f, 0x72, 0x3b, 0xf0, 0x22, 0xcf, 0x38, 0xea, 0x99, 0x3d, 0x86, 0xe9,
+	0xce, 0x4f, 0x35, 0x7d, 0xdb, 0xab, 0x81, 0x1c, 0xd9, 0x34, 0x8f, 0x34, 0x73, 0x79, 0x74, 0x7f,
+	0x84, 0xc3, 0x26, 0xb2, 0x19, 0x3d, 0xf2, 0x05, 0x4c, 0x36, 0x58, 0x71, 0x6a, 0x2c, 0xcd, 0xd5,
+	0xfe, 0xe9, 0x51, 0xf7, 0x4e, 0x5a, 0x5c, 0x4f, 0xb9, 0xdd, 0xaf, 0xe0, 0xa8, 0x09, 0x7d, 0xd7,
+	0xdc, 0x9d, 0xfe, 0x6b, 0x02, 0x5c, 0x71, 0x7e, 0x89, 0x6c, 0x97, 0x04, 0x48, 0xbe, 0x85, 0x99,
+	0x5e, 0xdf, 0x8f, 0xba, 0xdb, 0x07, 0x7b, 0x7d, 0xd1, 0x4b, 0xdb, 0xf4, 0xfb, 0x73, 0x98, 0xd4,
+	0xdb, 0x77, 0x14, 0x23, 0xcd, 0x77, 0x45, 0x7c, 0x0f, 0x56, 0xb3, 0x2e, 0xe9, 0x28, 0x48, 0x7b,
+	0xee, 0x8a, 0x7b, 0x01, 0x33, 0xad, 0xe3, 0xb8, 0xbe, 0xda, 0xb1, 0x18, 0xdc, 0x37, 0x90, 0xfe,
+	0x25, 0xd8, 0xad, 0x94, 0x8f, 0x47, 0xe1, 0x8d, 0x6b, 0xb1, 0x18, 0x5f, 0xd0, 0x86, 0xfd, 0x04,
+	0x4e, 0xb7, 0xf5, 0x16, 0x77, 0x54, 0xae, 0x7d, 0x8b, 0xf1, 0xe3, 0x90, 0x33, 0x70, 0xba, 0xe7,
+	0x18, 0xc7, 0xb6, 0xbe, 0xc5, 0x93, 0x71, 0x01, 0x5d, 0xe0, 0x19, 0x1c, 0x0c, 0xf7, 0xe0, 0x27,
+	0x63, 0xd1, 0xfb, 0xfe, 0x3b, 0x54, 0x7c, 0x6e, 0xfc, 0xfc, 0xd9, 0x5f, 0x9f, 0xbe, 0x4a, 0x8a,
+	0x18, 0xd9, 0x6f, 0x7e, 0x10, 0x27, 0x19, 0x3e, 0x6b, 0xfe, 0xdd, 0x5f, 0x34, 0x1f, 0x5f, 0xcf,
+	0xd4, 0x3f, 0xfc, 0x37, 0xff, 0x0f, 0x00, 0x0d, 0x3a, 0x07, 0x9c, 0xf9, 0x07, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// TssServiceClient is the client API for TssService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TssServiceClient interface {
+	// Keygen, Sign and Reshare return the session once it has ended
+	Keygen(ctx context.Context, in *RequestKeygen, opts ...grpc.CallOption) (*Session, error)
+	Sign(ctx context.Context, in *RequestSign, opts ...grpc.CallOption) (*Session, error)
+	Reshare(ctx context.Context, in *RequestReshare, opts ...grpc.CallOption) (*Session, error)
+	Verify(ctx context.Context, in *RequestVerify, opts ...grpc.CallOption) (*ResponseVerify, error)
+	ListKeys(ctx context.Context, in *RequestListKeys, opts ...grpc.CallOption) (*ResponseListKeys, error)
+	// RetireKey returns the info of the retired key
+	RetireKey(ctx context.Context, in *RequestRetireKey, opts ...grpc.CallOption) (*KeyInfo, error)
+	// DeleteKey is refused unless the node enables the unsafe rpc methods
+	DeleteKey(ctx context.Context, in *RequestDeleteKey, opts ...grpc.CallOption) (*ResponseDeleteKey, error)
+	// SessionEvents streams the sessions as they end
+	SessionEvents(ctx context.Context, in *RequestSessionEvents, opts ...grpc.CallOption) (TssService_SessionEventsClient, error)
+}
+
+type tssServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTssServiceClient(cc *grpc.ClientConn) TssServiceClient {
+	return &tssServiceClient{cc}
+}
+
+func (c *tssServiceClient) Keygen(ctx context.Context, in *RequestKeygen, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/coregrpc.TssService/Keygen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tssServiceClient) Sign(ctx context.Context, in *RequestSign, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/coregrpc.TssService/Sign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tssServiceClient) Reshare(ctx context.Context, in *RequestReshare, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/coregrpc.TssService/Reshare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tssServiceClient) Verify(ctx context.Context, in *RequestVerify, opts ...grpc.CallOption) (*ResponseVerify, error) {
+	out := new(ResponseVerify)
+	err := c.cc.Invoke(ctx, "/coregrpc.TssService/Verify", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tssServiceClient) ListKeys(ctx context.Context, in *RequestListKeys, opts ...grpc.CallOption) (*ResponseListKeys, error) {
+	out := new(ResponseListKeys)
+	err := c.cc.Invoke(ctx, "/coregrpc.TssService/ListKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tssServiceClient) RetireKey(ctx context.Context, in *RequestRetireKey, opts ...grpc.CallOption) (*KeyInfo, error) {
+	out := new(KeyInfo)
+	err := c.cc.Invoke(ctx, "/coregrpc.TssService/RetireKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tssServiceClient) DeleteKey(ctx context.Context, in *RequestDeleteKey, opts ...grpc.CallOption) (*ResponseDeleteKey, error) {
+	out := new(ResponseDeleteKey)
+	err := c.cc.Invoke(ctx, "/coregrpc.TssService/DeleteKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tssServiceClient) SessionEvents(ctx context.Context, in *RequestSessionEvents, opts ...grpc.CallOption) (TssService_SessionEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TssService_serviceDesc.Streams[0], "/coregrpc.TssService/SessionEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tssServiceSessionEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TssService_SessionEventsClient interface {
+	Recv() (*Session, error)
+	grpc.ClientStream
+}
+
+type tssServiceSessionEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *tssServiceSessionEventsClient) Recv() (*Session, error) {
+	m := new(Session)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TssServiceServer is the server API for TssService service.
+type TssServiceServer interface {
+	// Keygen, Sign and Reshare return the session once it has ended
+	Keygen(context.Context, *RequestKeygen) (*Session, error)
+	Sign(context.Context, *RequestSign) (*Session, error)
+	Reshare(context.Context, *RequestReshare) (*Session, error)
+	Verify(context.Context, *RequestVerify) (*ResponseVerify, error)
+	ListKeys(context.Context, *RequestListKeys) (*ResponseListKeys, error)
+	// RetireKey returns the info of the retired key
+	RetireKey(context.Context, *RequestRetireKey) (*KeyInfo, error)
+	// DeleteKey is refused unless the node enables the unsafe rpc methods
+	DeleteKey(context.Context, *RequestDeleteKey) (*ResponseDeleteKey, error)
+	// SessionEvents streams the sessions as they end
+	SessionEvents(*RequestSessionEvents, TssService_SessionEventsServer) error
+}
+
+// UnimplementedTssServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedTssServiceServer struct {
+}
+
+func (*UnimplementedTssServiceServer) Keygen(ctx context.Context, req *RequestKeygen) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Keygen not implemented")
+}
+func (*UnimplementedTssServiceServer) Sign(ctx context.Context, req *RequestSign) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sign not implemented")
+}
+func (*UnimplementedTssServiceServer) Reshare(ctx context.Context, req *RequestReshare) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reshare not implemented")
+}
+func (*UnimplementedTssServiceServer) Verify(ctx context.Context, req *RequestVerify) (*ResponseVerify, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Verify not implemented")
+}
+func (*UnimplementedTssServiceServer) ListKeys(ctx context.Context, req *RequestListKeys) (*ResponseListKeys, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKeys not implemented")
+}
+func (*UnimplementedTssServiceServer) RetireKey(ctx context.Context, req *RequestRetireKey) (*KeyInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetireKey not implemented")
+}
+func (*UnimplementedTssServiceServer) DeleteKey(ctx context.Context, req *RequestDeleteKey) (*ResponseDeleteKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteKey not implemented")
+}
+func (*UnimplementedTssServiceServer) SessionEvents(req *RequestSessionEvents, srv TssService_SessionEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SessionEvents not implemented")
+}
+
+func RegisterTssServiceServer(s *grpc.Server, srv TssServiceServer) {
+	s.RegisterService(&_TssService_serviceDesc, srv)
+}
+
+func _TssService_Keygen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestKeygen)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TssServiceServer).Keygen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/coregrpc.TssService/Keygen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TssServiceServer).Keygen(ctx, req.(*RequestKeygen))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TssService_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestSign)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TssServiceServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/coregrpc.TssService/Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TssServiceServer).Sign(ctx, req.(*RequestSign))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TssService_Reshare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestReshare)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TssServiceServer).Reshare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/coregrpc.TssService/Reshare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TssServiceServer).Reshare(ctx, req.(*RequestReshare))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TssService_Verify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestVerify)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TssServiceServer).Verify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/coregrpc.TssService/Verify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TssServiceServer).Verify(ctx, req.(*RequestVerify))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TssService_ListKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestListKeys)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TssServiceServer).ListKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/coregrpc.TssService/ListKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TssServiceServer).ListKeys(ctx, req.(*RequestListKeys))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TssService_RetireKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestRetireKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TssServiceServer).RetireKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/coregrpc.TssService/RetireKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TssServiceServer).RetireKey(ctx, req.(*RequestRetireKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TssService_DeleteKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestDeleteKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TssServiceServer).DeleteKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/coregrpc.TssService/DeleteKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TssServiceServer).DeleteKey(ctx, req.(*RequestDeleteKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TssService_SessionEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestSessionEvents)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TssServiceServer).SessionEvents(m, &tssServiceSessionEventsServer{stream})
+}
+
+type TssService_SessionEventsServer interface {
+	Send(*Session) error
+	grpc.ServerStream
+}
+
+type tssServiceSessionEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *tssServiceSessionEventsServer) Send(m *Session) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _TssService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "coregrpc.TssService",
+	HandlerType: (*TssServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Keygen",
+			Handler:    _TssService_Keygen_Handler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    _TssService_Sign_Handler,
+		},
+		{
+			MethodName: "Reshare",
+			Handler:    _TssService_Reshare_Handler,
+		},
+		{
+			MethodName: "Verify",
+			Handler:    _TssService_Verify_Handler,
+		},
+		{
+			MethodName: "ListKeys",
+			Handler:    _TssService_ListKeys_Handler,
+		},
+		{
+			MethodName: "RetireKey",
+			Handler:    _TssService_RetireKey_Handler,
+		},
+		{
+			MethodName: "DeleteKey",
+			Handler:    _TssService_DeleteKey_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SessionEvents",
+			Handler:       _TssService_SessionEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc/grpc/types.proto",
+}
@@ -41,6 +41,13 @@ func (s Store) Delete(key []byte) {
 	s.parent.Delete(s.key(key))
 }
 
+// Iterator iterates over the keys of the store that start with prefix, in
+// ascending order. The keys of the iterator are without the store prefix, the
+// caller must close it.
+func (s Store) Iterator(prefix []byte) dbm.Iterator {
+	return dbm.IteratePrefix(dbm.NewPrefixDB(s.parent, s.prefix), prefix)
+}
+
 func (s Store) key(key []byte) (res []byte) {
 	if key == nil {
 		panic("nil key on Store")
@@ -0,0 +1,102 @@
+package threshold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"CipherMachine/p2p"
+	"CipherMachine/tsslib/tss"
+)
+
+var (
+	errUnsignedMsg = errors.New("msg is not signed")
+	errNoSender    = errors.New("msg has no sending party")
+)
+
+// signBytes returns the bytes the sender of msg signs, the encoded msg
+// without its signature.
+func (msg TssMessage) signBytes() ([]byte, error) {
+	msg.Signature = nil
+	return cdc.MarshalBinaryBare(msg)
+}
+
+// VerifySignature checks that msg is signed by the node key in msg.PubKey
+// and that this node is the party msg claims to be from. A signed msg can be
+// checked without knowing the peer it was received from.
+func (msg *TssMessage) VerifySignature() error {
+	if len(msg.Signature) == 0 {
+		return errUnsignedMsg
+	}
+	if msg.PubKey == nil {
+		return errors.New("signed msg has no pubkey")
+	}
+	signer := p2p.PubKeyToID(msg.PubKey)
+	if msg.From != nil && msg.From.Id != string(signer) {
+		return fmt.Errorf("msg of party %s is signed by %s", msg.From.Id, signer)
+	}
+	bz, err := msg.signBytes()
+	if err != nil {
+		return err
+	}
+	if !msg.PubKey.VerifyBytes(bz, msg.Signature) {
+		return fmt.Errorf("invalid signature of %s", signer)
+	}
+	return nil
+}
+
+// signMsg signs msg with the node key of the switch.
+func (tsr *TssReactor) signMsg(msg *TssMessage) error {
+	nodeKey := tsr.Switch.NodeKey()
+	if nodeKey == nil {
+		return errors.New("no node key to sign the msg")
+	}
+	msg.PubKey = nodeKey.PubKey()
+	bz, err := msg.signBytes()
+	if err != nil {
+		return err
+	}
+	sig, err := nodeKey.PrivKey.Sign(bz)
+	if err != nil {
+		return err
+	}
+	msg.Signature = sig
+	return nil
+}
+
+// authenticateMsg checks that msg was sent by the party it claims to be from.
+// The id of src is authenticated by the p2p handshake, so the claimed party
+// id must match it, and a signed msg must be signed by the node key of src.
+// Only resharing announcements, which carry no party msg, may have no party.
+func (tsr *TssReactor) authenticateMsg(src p2p.ID, msg *TssMessage) error {
+	if msg.From == nil && (msg.PmsgType != ResharingMsg || len(msg.Pmsg) != 0) {
+		return errNoSender
+	}
+	if msg.From != nil && msg.From.Id != string(src) {
+		return fmt.Errorf("peer %s sent a msg of party %s", src, msg.From.Id)
+	}
+	if len(msg.Signature) == 0 {
+		if tsr.tssConfig.SignMessages {
+			return errUnsignedMsg
+		}
+		return nil
+	}
+	if err := msg.VerifySignature(); err != nil {
+		return err
+	}
+	if signer := p2p.PubKeyToID(msg.PubKey); signer != src {
+		return fmt.Errorf("peer %s sent a msg signed by %s", src, signer)
+	}
+	return nil
+}
+
+// isParty reports whether from is one of the parties of session s, with the
+// index and key the session knows it by.
+func (s *session) isParty(from *tss.PartyID) bool {
+	for _, pID := range s.pIDs {
+		if pID.Id == from.Id && pID.Index == from.Index && bytes.Equal(pID.Key, from.Key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package threshold
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+
+	"CipherMachine/tsslib/crypto"
+	"golang.org/x/crypto/scrypt"
+)
+
+// version of the share backup file format
+const backupVersion = 1
+
+// shareBackup is the file a share is exported to. The SaveData is encrypted
+// with a key derived from the backup passphrase, the version and key id are
+// authenticated with it.
+type shareBackup struct {
+	Version    int    `json:"version"`
+	KeyID      KeyID  `json:"key_id"`
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func (b *shareBackup) additionalData() []byte {
+	return []byte(fmt.Sprintf("%d/%s", b.Version, b.KeyID))
+}
+
+func (b *shareBackup) key(passphrase string) ([]byte, error) {
+	if b.KDF != kdfScrypt {
+		return nil, fmt.Errorf("unknown kdf %s", b.KDF)
+	}
+	return scrypt.Key([]byte(passphrase), b.Salt, b.N, b.R, b.P, 32)
+}
+
+// ExportShare writes the share of keyID to a new backup file at path,
+// encrypted with passphrase.
+func (tsr *TssReactor) ExportShare(keyID KeyID, path string, passphrase string) error {
+	if passphrase == "" {
+		return errors.New("no backup passphrase")
+	}
+	saveData, err := tsr.getSaveData(keyID)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(saveData)
+	if err != nil {
+		return err
+	}
+	b := &shareBackup{Version: backupVersion, KeyID: keyID, KDF: kdfScrypt, Salt: make([]byte, 32), N: scryptN, R: scryptR, P: scryptP}
+	if _, err := rand.Read(b.Salt); err != nil {
+		return err
+	}
+	key, err := b.key(passphrase)
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	b.Ciphertext = aead.Seal(nonce, nonce, plain, b.additionalData())
+	bz, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// never overwrite another backup
+	fd, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := fd.Write(bz); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
+
+// ImportShare restores the share in the backup file at path. The share must
+// belong to this node and be consistent with the public key, a key that is
+// already stored is not replaced.
+func (tsr *TssReactor) ImportShare(path string, passphrase string) (KeyID, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	b := new(shareBackup)
+	if err := json.Unmarshal(bz, b); err != nil {
+		return "", fmt.Errorf("invalid backup file: %v", err)
+	}
+	if b.Version != backupVersion {
+		return "", fmt.Errorf("unsupported backup version %d", b.Version)
+	}
+	key, err := b.key(passphrase)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonceSize := aead.NonceSize()
+	if len(b.Ciphertext) < nonceSize {
+		return "", errors.New("invalid backup file: ciphertext is too short")
+	}
+	plain, err := aead.Open(nil, b.Ciphertext[:nonceSize], b.Ciphertext[nonceSize:], b.additionalData())
+	if err != nil {
+		return "", errors.New("wrong backup passphrase")
+	}
+	var saveData SaveData
+	if err := json.Unmarshal(plain, &saveData); err != nil {
+		return "", fmt.Errorf("invalid backup: %v", err)
+	}
+
+	if saveData.PartySaveData == nil || saveData.ConfigSaveData == nil {
+		return "", errors.New("invalid backup: incomplete save data")
+	}
+	if err := tsr.validateSaveData(saveData); err != nil {
+		return "", err
+	}
+	if err := validateShare(saveData); err != nil {
+		return "", err
+	}
+	if tsr.tssStore.Has(tsr.newPrefixKey(SaveDataKey, b.KeyID)) {
+		return "", fmt.Errorf("key %s exists", b.KeyID)
+	}
+	if err := tsr.setSaveData(b.KeyID, saveData); err != nil {
+		return "", err
+	}
+	tsr.updateKeyInfo(b.KeyID, saveData)
+	tsr.Logger.Info("share imported", "key", b.KeyID)
+	return b.KeyID, nil
+}
+
+// validateShare checks that the share of the local party matches its public
+// share Xi*G = BigXj[i], and that the public shares of the committee add up
+// to the public key.
+func validateShare(saveData SaveData) error {
+	key := saveData.PartySaveData.share()
+	pIDs := saveData.PartySaveData.SortedPartyIDs
+	threshold := saveData.ConfigSaveData.Thresold
+	i := findPartyIndex(pIDs, saveData.ConfigSaveData.LocalAddr)
+	if i < 0 {
+		return errors.New("local node is not in the committee of the share")
+	}
+	if key == nil || key.Xi == nil || key.ShareID == nil || key.PubKey == nil || len(key.Ks) != len(pIDs) || len(key.BigXj) != len(pIDs) {
+		return errors.New("incomplete share")
+	}
+	if threshold < 1 || threshold >= len(pIDs) {
+		return fmt.Errorf("invalid threshold %d for %d parties", threshold, len(pIDs))
+	}
+	if key.ShareID.Cmp(key.Ks[i]) != 0 {
+		return fmt.Errorf("share id does not match Ks[%d]", i)
+	}
+	if !crypto.ScalarBaseMult(key.ec, key.Xi).Equals(key.BigXj[i]) {
+		return fmt.Errorf("Xi*G does not match BigXj[%d]", i)
+	}
+
+	// interpolate the public shares of threshold+1 parties at 0
+	q := key.ec.Params().N
+	var pub *crypto.ECPoint
+	for j := 0; j <= threshold; j++ {
+		lambda := big.NewInt(1)
+		for m := 0; m <= threshold; m++ {
+			if m == j {
+				continue
+			}
+			den := new(big.Int).Sub(key.Ks[m], key.Ks[j])
+			den.Mod(den, q)
+			if den.Sign() == 0 {
+				return errors.New("duplicate share ids")
+			}
+			lambda.Mul(lambda, key.Ks[m])
+			lambda.Mul(lambda, den.ModInverse(den, q))
+			lambda.Mod(lambda, q)
+		}
+		if key.BigXj[j] == nil {
+			return errors.New("incomplete share")
+		}
+		term := key.BigXj[j].ScalarMult(lambda)
+		if pub == nil {
+			pub = term
+			continue
+		}
+		var err error
+		if pub, err = pub.Add(term); err != nil {
+			return err
+		}
+	}
+	if !pub.Equals(key.PubKey) {
+		return errors.New("public shares do not match the public key")
+	}
+	return nil
+}
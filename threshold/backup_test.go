@@ -0,0 +1,102 @@
+package threshold
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	cfg "CipherMachine/config"
+	"CipherMachine/tsslib/ecdsa/keygen"
+	"CipherMachine/tsslib/tss"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestShareBackup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "key.backup")
+
+	saveData := loadTestSaveData(t, 0)
+	tsr := newBackupTestReactor(t, "aa")
+	require.NoError(t, tsr.setSaveData("key", saveData))
+	require.NoError(t, tsr.ExportShare("key", path, "backup-secret"))
+	// a backup is never overwritten
+	require.Error(t, tsr.ExportShare("key", path, "backup-secret"))
+	bz, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(bz), saveData.PartySaveData.LocalPartySaveData.Xi.String())
+
+	// restore onto a rebuilt node
+	rebuilt := newBackupTestReactor(t, "aa")
+	_, err = rebuilt.ImportShare(path, "wrong")
+	require.Error(t, err)
+	keyID, err := rebuilt.ImportShare(path, "backup-secret")
+	require.NoError(t, err)
+	require.Equal(t, KeyID("key"), keyID)
+	got, err := rebuilt.getSaveData("key")
+	require.NoError(t, err)
+	require.True(t, got.PartySaveData.LocalPartySaveData.ECDSAPub.Equals(saveData.PartySaveData.LocalPartySaveData.ECDSAPub))
+	// the key is not replaced
+	_, err = rebuilt.ImportShare(path, "backup-secret")
+	require.Error(t, err)
+
+	// the share belongs to another node
+	_, err = newBackupTestReactor(t, "bb").ImportShare(path, "backup-secret")
+	require.Error(t, err)
+}
+
+func TestValidateShare(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		require.NoError(t, validateShare(loadTestSaveData(t, i)))
+	}
+
+	saveData := loadTestSaveData(t, 0)
+	key := &saveData.PartySaveData.LocalPartySaveData
+	key.Xi = new(big.Int).Add(key.Xi, big.NewInt(1))
+	require.Error(t, validateShare(saveData))
+
+	saveData = loadTestSaveData(t, 0)
+	key = &saveData.PartySaveData.LocalPartySaveData
+	key.ECDSAPub = key.BigXj[1]
+	require.Error(t, validateShare(saveData))
+}
+
+func newBackupTestReactor(t *testing.T, addr string) *TssReactor {
+	db := dbm.NewMemDB()
+	require.NoError(t, InitStore(db, StoreSecret{Passphrase: "test"}))
+	tsr := NewTssReactor(cfg.TestConfig(), db, addr)
+	tsr.peers = []string{"aa", "bb"}
+	require.NoError(t, tsr.Unlock(StoreSecret{Passphrase: "test"}))
+	return tsr
+}
+
+// loadTestSaveData returns the share of party i of the 2 party key in the
+// test fixtures, the parties are the nodes aa and bb.
+func loadTestSaveData(t *testing.T, i int) SaveData {
+	_, file, _, _ := runtime.Caller(0)
+	var shares [2]keygen.LocalPartySaveData
+	for j := range shares {
+		bz, err := ioutil.ReadFile(filepath.Join(filepath.Dir(file), "test", fmt.Sprintf("keygen_data_%d.json", j)))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bz, &shares[j]))
+	}
+	pIDs := tss.SortPartyIDs(tss.UnSortedPartyIDs{
+		tss.NewPartyID("aa", "", shares[0].ShareID),
+		tss.NewPartyID("bb", "", shares[1].ShareID),
+	})
+	return SaveData{
+		PartySaveData: &PartySaveData{LocalPartySaveData: shares[i], SortedPartyIDs: pIDs},
+		ConfigSaveData: &ConfigSaveData{
+			LocalAddr: pIDs[i].Id,
+			Peers:     []string{"aa", "bb"},
+			Thresold:  1,
+		},
+	}
+}
@@ -3,11 +3,13 @@ package threshold
 import (
 	"CipherMachine/tsslib/tss"
 	"github.com/tendermint/go-amino"
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
 )
 
 var cdc = amino.NewCodec()
 
 func init() {
+	cryptoAmino.RegisterAmino(cdc)
 	RegisterTssMessages(cdc)
 	//RegisterSaveDatas(cdc)
 }
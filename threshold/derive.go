@@ -0,0 +1,154 @@
+package threshold
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/ecdsa/keygen"
+	frostsigning "CipherMachine/tsslib/frost/signing"
+)
+
+// HardenedKeyStart is the first hardened BIP32 child index. Hardened children
+// need the private key, so a threshold key only derives the children below.
+const HardenedKeyStart = uint32(1) << 31
+
+const chainCodeTag = "CipherMachine/chaincode"
+
+var errNoChildren = errors.New("only ECDSA keys have BIP32 children")
+
+// newChainCode returns the BIP32 chain code of a key, a hash of the public
+// shares of the keygen. The committee agrees on it without another round and
+// nobody outside the committee knows it.
+func newChainCode(bigXj []*crypto.ECPoint) []byte {
+	h := sha256.New()
+	h.Write([]byte(chainCodeTag))
+	for _, X := range bigXj {
+		h.Write(X.SerializeCompressed())
+	}
+	return h.Sum(nil)
+}
+
+// chainCodeOf returns the chain code of saveData. Keys generated before the
+// chain code was stored take it from their current public shares, EdDSA and
+// Schnorr keys have none.
+func chainCodeOf(saveData SaveData) []byte {
+	if saveData.PartySaveData.keyType() != KeyTypeECDSA {
+		return nil
+	}
+	if len(saveData.PartySaveData.ChainCode) != 0 {
+		return saveData.PartySaveData.ChainCode
+	}
+	return newChainCode(saveData.PartySaveData.LocalPartySaveData.BigXj)
+}
+
+// deriveChild returns the public key of the non-hardened BIP32 child at path
+// of pub and chainCode, and the sum of the child offsets along path, the
+// tweak of the secret.
+func deriveChild(pub *crypto.ECPoint, chainCode []byte, path []uint32) (*crypto.ECPoint, *big.Int, error) {
+	q := pub.Curve().Params().N
+	tweak := new(big.Int)
+	for depth, index := range path {
+		if index >= HardenedKeyStart {
+			return nil, nil, fmt.Errorf("hardened child %d at depth %d cannot be derived from a threshold key", index, depth+1)
+		}
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(pub.SerializeCompressed())
+		var bz [4]byte
+		binary.BigEndian.PutUint32(bz[:], index)
+		mac.Write(bz[:])
+		I := mac.Sum(nil)
+
+		il := new(big.Int).SetBytes(I[:32])
+		if il.Cmp(q) >= 0 {
+			return nil, nil, fmt.Errorf("child %d at depth %d is invalid, use the next index", index, depth+1)
+		}
+		child, err := pub.Add(crypto.ScalarBaseMult(pub.Curve(), il))
+		if err != nil {
+			return nil, nil, fmt.Errorf("child %d at depth %d is invalid, use the next index", index, depth+1)
+		}
+		pub, chainCode = child, I[32:]
+		tweak.Add(tweak, il)
+		tweak.Mod(tweak, q)
+	}
+	return pub, tweak, nil
+}
+
+// tweakKey returns the share of the child key at path. Adding the tweak to
+// the secret adds it to the constant term of the sharing polynomial, so every
+// share Xi and public share BigXj moves by it.
+func tweakKey(key keygen.LocalPartySaveData, chainCode []byte, path []uint32) (keygen.LocalPartySaveData, error) {
+	if len(path) == 0 {
+		return key, nil
+	}
+	if key.ECDSAPub == nil || key.Xi == nil {
+		return key, errors.New("incomplete share")
+	}
+	childPub, tweak, err := deriveChild(key.ECDSAPub, chainCode, path)
+	if err != nil {
+		return key, err
+	}
+	ec := key.ECDSAPub.Curve()
+	tweakPoint := crypto.ScalarBaseMult(ec, tweak)
+	key.ECDSAPub = childPub
+	key.Xi = new(big.Int).Mod(new(big.Int).Add(key.Xi, tweak), ec.Params().N)
+	bigXj := make([]*crypto.ECPoint, len(key.BigXj))
+	for j, X := range key.BigXj {
+		if bigXj[j], err = X.Add(tweakPoint); err != nil {
+			return key, err
+		}
+	}
+	key.BigXj = bigXj
+	return key, nil
+}
+
+// DerivePubKey returns the public key of the non-hardened BIP32 child at path
+// of the key of keyID, a signing with the same path signs with it.
+func (tsr *TssReactor) DerivePubKey(keyID KeyID, path []uint32) (*crypto.ECPoint, error) {
+	saveData, err := tsr.getSaveData(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if saveData.PartySaveData.keyType() != KeyTypeECDSA {
+		return nil, errNoChildren
+	}
+	pub, _, err := deriveChild(saveData.PartySaveData.LocalPartySaveData.ECDSAPub, chainCodeOf(saveData), path)
+	return pub, err
+}
+
+// ChainCode returns the BIP32 chain code of the key of keyID, with the public
+// key it makes the extended public key of the key.
+func (tsr *TssReactor) ChainCode(keyID KeyID) ([]byte, error) {
+	saveData, err := tsr.getSaveData(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if saveData.PartySaveData.keyType() != KeyTypeECDSA {
+		return nil, errNoChildren
+	}
+	return chainCodeOf(saveData), nil
+}
+
+// TaprootOutputKey returns the BIP341 output key of the Schnorr key of keyID
+// for the script tree of merkleRoot, a signing with the same TaprootTweak
+// signs with it.
+func (tsr *TssReactor) TaprootOutputKey(keyID KeyID, merkleRoot []byte) (*crypto.ECPoint, error) {
+	saveData, err := tsr.getSaveData(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if saveData.PartySaveData.keyType() != KeyTypeSchnorr {
+		return nil, errors.New("only Schnorr keys have taproot outputs")
+	}
+	pub := saveData.PartySaveData.pubKey()
+	if pub == nil {
+		return nil, errors.New("incomplete save data")
+	}
+	Q, _, err := frostsigning.TaprootOutputKey(pub, merkleRoot)
+	return Q, err
+}
@@ -0,0 +1,93 @@
+package threshold
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"CipherMachine/tsslib/tss"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/stretchr/testify/require"
+)
+
+// hdChild derives the child at path of pub and chainCode with hdkeychain.
+func hdChild(t *testing.T, pub []byte, chainCode []byte, path []uint32) []byte {
+	key := hdkeychain.NewExtendedKey(chaincfg.MainNetParams.HDPublicKeyID[:], pub, chainCode, []byte{0, 0, 0, 0}, 0, 0, false)
+	for _, index := range path {
+		var err error
+		key, err = key.Child(index)
+		require.NoError(t, err)
+	}
+	childPub, err := key.ECPubKey()
+	require.NoError(t, err)
+	return childPub.SerializeCompressed()
+}
+
+func TestDeriveChild(t *testing.T) {
+	saveData := loadTestSaveData(t, 0)
+	key := saveData.PartySaveData.LocalPartySaveData
+	chainCode := chainCodeOf(saveData)
+	require.Len(t, chainCode, 32)
+	// every party takes the same chain code
+	require.Equal(t, chainCode, chainCodeOf(loadTestSaveData(t, 1)))
+
+	for _, path := range [][]uint32{{0}, {1, 7}, {44, 0, HardenedKeyStart - 1, 3}} {
+		child, _, err := deriveChild(key.ECDSAPub, chainCode, path)
+		require.NoError(t, err)
+		require.Equal(t, hdChild(t, key.ECDSAPub.SerializeCompressed(), chainCode, path), child.SerializeCompressed(), path)
+
+		// the tweaked shares are the shares of the child key
+		for i := 0; i < 2; i++ {
+			share := loadTestSaveData(t, i)
+			tweaked, err := tweakKey(share.PartySaveData.LocalPartySaveData, chainCode, path)
+			require.NoError(t, err)
+			require.True(t, tweaked.ECDSAPub.Equals(child))
+			share.PartySaveData.LocalPartySaveData = tweaked
+			require.NoError(t, validateShare(share))
+		}
+	}
+
+	_, _, err := deriveChild(key.ECDSAPub, chainCode, []uint32{0, HardenedKeyStart})
+	require.Error(t, err)
+	tweaked, err := tweakKey(key, chainCode, nil)
+	require.NoError(t, err)
+	require.Equal(t, key.Xi, tweaked.Xi)
+}
+
+func TestSignChild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	reactors := makeTestReactors(t, 3)
+	ctx := context.Background()
+	res := reactors[0].Keygen(ctx, KeygenRequest{KeyID: "key", Threshold: 1})
+	require.Nil(t, res.Err)
+	waitForShares(t, reactors, "key")
+
+	chainCode, err := reactors[0].ChainCode("key")
+	require.NoError(t, err)
+	for _, r := range reactors[1:] {
+		cc, err := r.ChainCode("key")
+		require.NoError(t, err)
+		require.Equal(t, chainCode, cc)
+	}
+
+	path := []uint32{0, 5}
+	child, err := reactors[1].DerivePubKey("key", path)
+	require.NoError(t, err)
+	require.Equal(t, hdChild(t, res.PubKey.SerializeCompressed(), chainCode, path), child.SerializeCompressed())
+
+	msg := big.NewInt(42)
+	sres := reactors[2].Sign(ctx, SignRequest{KeyID: "key", Msg: msg, Path: path})
+	require.Nil(t, sres.Err)
+	pub := &ecdsa.PublicKey{Curve: tss.EC(), X: child.X(), Y: child.Y()}
+	r, s := new(big.Int).SetBytes(sres.Signature.R), new(big.Int).SetBytes(sres.Signature.S)
+	require.True(t, ecdsa.Verify(pub, msg.Bytes(), r, s))
+	// the parent key did not sign it
+	require.Error(t, reactors[0].Verify(msg, "key", *sres.Signature))
+
+	sres = reactors[0].Sign(ctx, SignRequest{KeyID: "key", Msg: msg, Path: []uint32{HardenedKeyStart}})
+	require.NotNil(t, sres.Err)
+}
@@ -0,0 +1,135 @@
+package threshold
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/ecdsa/signing"
+	"CipherMachine/tsslib/tss"
+	"golang.org/x/crypto/sha3"
+)
+
+// HashAlg names how the digest a signature is made over was computed from the
+// message.
+type HashAlg string
+
+const (
+	// HashNone is a digest the caller computed
+	HashNone HashAlg = "none"
+	// HashSHA256 is the SHA-256 of the message
+	HashSHA256 HashAlg = "sha256"
+	// HashDoubleSHA256 is the SHA-256 of the SHA-256 of the message, as in bitcoin
+	HashDoubleSHA256 HashAlg = "sha256d"
+	// HashKeccak256 is the legacy Keccak-256 of the message, as in ethereum
+	HashKeccak256 HashAlg = "keccak256"
+)
+
+// HashMessage returns the digest of data with alg.
+func HashMessage(data []byte, alg HashAlg) ([32]byte, error) {
+	var digest [32]byte
+	switch alg {
+	case HashSHA256:
+		digest = sha256.Sum256(data)
+	case HashDoubleSHA256:
+		first := sha256.Sum256(data)
+		digest = sha256.Sum256(first[:])
+	case HashKeccak256:
+		h := sha3.NewLegacyKeccak256()
+		h.Write(data)
+		copy(digest[:], h.Sum(nil))
+	default:
+		return digest, fmt.Errorf("unknown hash algorithm %q", alg)
+	}
+	return digest, nil
+}
+
+// SignDigest signs the 32 byte digest with the key of keyID. It blocks like
+// Sign.
+func (tsr *TssReactor) SignDigest(ctx context.Context, keyID KeyID, digest [32]byte) *SignResult {
+	return tsr.Sign(ctx, SignRequest{KeyID: keyID, Msg: new(big.Int).SetBytes(digest[:]), HashAlg: HashNone})
+}
+
+// SignMessage hashes data with alg and signs the digest with the key of
+// keyID. With HashNone data is signed as is, as EdDSA and Schnorr keys sign
+// messages.
+// It blocks like Sign.
+func (tsr *TssReactor) SignMessage(ctx context.Context, keyID KeyID, data []byte, alg HashAlg) *SignResult {
+	if alg == HashNone {
+		if data == nil {
+			data = []byte{}
+		}
+		return tsr.Sign(ctx, SignRequest{KeyID: keyID, Data: data, HashAlg: alg})
+	}
+	digest, err := HashMessage(data, alg)
+	if err != nil {
+		return &SignResult{KeyID: keyID, HashAlg: alg, Err: tss.NewError(err, signing.TaskName, -1, nil)}
+	}
+	return tsr.Sign(ctx, SignRequest{KeyID: keyID, Msg: new(big.Int).SetBytes(digest[:]), HashAlg: alg})
+}
+
+// VerifyMessage checks signature of data hashed with alg with the key of
+// keyID. With HashNone an EdDSA or Schnorr key verifies data as is, an ECDSA
+// key takes it for the digest.
+func (tsr *TssReactor) VerifyMessage(keyID KeyID, data []byte, alg HashAlg, signature common.SignatureData) error {
+	if alg != HashNone {
+		digest, err := HashMessage(data, alg)
+		if err != nil {
+			return err
+		}
+		return tsr.VerifyDigest(keyID, digest, signature)
+	}
+	saveData, err := tsr.getSaveData(keyID)
+	if err != nil {
+		return err
+	}
+	switch saveData.PartySaveData.keyType() {
+	case KeyTypeEdDSA, KeyTypeSchnorr:
+		return tsr.verifyMessage(saveData, data, signature)
+	}
+	if len(data) > 32 {
+		return fmt.Errorf("msg is not a 32 byte digest")
+	}
+	var digest [32]byte
+	copy(digest[32-len(data):], data)
+	return tsr.VerifyDigest(keyID, digest, signature)
+}
+
+// verifyMessage checks signature of msg with the EdDSA or Schnorr key of
+// saveData. A signature recording its message in M must record this one.
+func (tsr *TssReactor) verifyMessage(saveData SaveData, msg []byte, signature common.SignatureData) error {
+	if len(signature.M) != 0 && !bytes.Equal(signature.M, msg) {
+		return errors.New("signature is of another message")
+	}
+	pub := saveData.PartySaveData.pubKey()
+	if pub == nil {
+		return errors.New("incomplete save data")
+	}
+	verify := verifyEdDSA
+	if saveData.PartySaveData.keyType() == KeyTypeSchnorr {
+		verify = verifySchnorr
+	}
+	if err := verify(pub, msg, signature); err != nil {
+		tsr.Logger.Error(err.Error())
+		return err
+	}
+	tsr.Logger.Info("verify success!")
+	return nil
+}
+
+// digestOf returns msg as a 32 byte digest, with its leading zero bytes.
+func digestOf(msg *big.Int) ([32]byte, error) {
+	var digest [32]byte
+	if msg == nil {
+		return digest, fmt.Errorf("no msg to sign")
+	}
+	if msg.Sign() < 0 || msg.BitLen() > 256 {
+		return digest, fmt.Errorf("msg is not a 32 byte digest")
+	}
+	msg.FillBytes(digest[:])
+	return digest, nil
+}
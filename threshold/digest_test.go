@@ -0,0 +1,72 @@
+package threshold
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashMessage(t *testing.T) {
+	tests := []struct {
+		alg    HashAlg
+		data   string
+		digest string
+	}{
+		{HashSHA256, "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{HashDoubleSHA256, "hello", "9595c9df90075148eb06860365df33584b75bff782a510c6cd4883a419833d50"},
+		{HashKeccak256, "", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+	}
+	for _, tt := range tests {
+		digest, err := HashMessage([]byte(tt.data), tt.alg)
+		require.NoError(t, err)
+		require.Equal(t, tt.digest, hex.EncodeToString(digest[:]), tt.alg)
+	}
+	_, err := HashMessage(nil, HashNone)
+	require.Error(t, err)
+
+	// a digest keeps its leading zero bytes, longer msgs are refused
+	digest, err := digestOf(big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, byte(1), digest[31])
+	_, err = digestOf(new(big.Int).Lsh(big.NewInt(1), 256))
+	require.Error(t, err)
+}
+
+func TestSignDigest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	reactors := makeTestReactors(t, 3)
+	ctx := context.Background()
+	res := reactors[0].Keygen(ctx, KeygenRequest{KeyID: "key", Threshold: 1})
+	require.Nil(t, res.Err)
+	waitForShares(t, reactors, "key")
+
+	var digest [32]byte
+	copy(digest[2:], []byte("digest with leading zero bytes"))
+	sres := reactors[0].SignDigest(ctx, "key", digest)
+	require.Nil(t, sres.Err)
+	require.Equal(t, HashNone, sres.HashAlg)
+	require.Equal(t, digest[:], sres.Signature.M)
+	for _, r := range reactors {
+		require.NoError(t, r.VerifyDigest("key", digest, *sres.Signature))
+	}
+	require.NoError(t, reactors[1].Verify(new(big.Int).SetBytes(digest[:]), "key", *sres.Signature))
+	other := digest
+	other[31]++
+	require.Error(t, reactors[1].VerifyDigest("key", other, *sres.Signature))
+
+	data := []byte("message")
+	sres = reactors[1].SignMessage(ctx, "key", data, HashKeccak256)
+	require.Nil(t, sres.Err)
+	require.Equal(t, HashKeccak256, sres.HashAlg)
+	digest, err := HashMessage(data, sres.HashAlg)
+	require.NoError(t, err)
+	require.NoError(t, reactors[2].VerifyDigest("key", digest, *sres.Signature))
+
+	sres = reactors[1].SignMessage(ctx, "key", data, "md5")
+	require.NotNil(t, sres.Err)
+}
@@ -0,0 +1,76 @@
+package threshold
+
+import (
+	"context"
+	"sync"
+
+	"CipherMachine/tsslib/tss"
+)
+
+type inboxMsg struct {
+	pMsg tss.ParsedMessage
+	//resharing only, the committee of the receiving party
+	toOld bool
+}
+
+// sessionInbox queues the inbound msgs of a session. Msgs are held until the
+// parties of the session are started, then delivered in arrival order on the
+// inbox routine, so the p2p receive routine never waits on a party.
+type sessionInbox struct {
+	mtx    sync.Mutex
+	msgs   []inboxMsg
+	notify chan struct{}
+}
+
+func newSessionInbox() *sessionInbox {
+	return &sessionInbox{
+		notify: make(chan struct{}, 1),
+	}
+}
+
+func (ib *sessionInbox) push(msg inboxMsg) {
+	ib.mtx.Lock()
+	ib.msgs = append(ib.msgs, msg)
+	ib.mtx.Unlock()
+	select {
+	case ib.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (ib *sessionInbox) drain() []inboxMsg {
+	ib.mtx.Lock()
+	defer ib.mtx.Unlock()
+	msgs := ib.msgs
+	ib.msgs = nil
+	return msgs
+}
+
+// inboxRoutine starts the parties of a session and then feeds them the queued
+// msgs until ctx is done. route picks the party a msg is meant for.
+func (tsr *TssReactor) inboxRoutine(ctx context.Context, ib *sessionInbox, parties []tss.Party, route func(inboxMsg) tss.Party, errCh chan *tss.Error) {
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			reportError(errCh, err)
+			return
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ib.notify:
+			for _, msg := range ib.drain() {
+				party := route(msg)
+				if party == nil {
+					tsr.Logger.Error("no party for msg", "from", msg.pMsg.GetFrom().Id, "toOldCommittee", msg.toOld)
+					continue
+				}
+				if _, err := party.Update(msg.pMsg); err != nil {
+					reportError(errCh, err)
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package threshold
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"CipherMachine/store"
+	dbm "github.com/tendermint/tm-db"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keyringKey = "Keyring"
+
+	// PassphraseEnv names the environment variable the node reads the
+	// passphrase of the tss store from.
+	PassphraseEnv = "CIPHER_TSS_PASSPHRASE"
+
+	kdfScrypt  = "scrypt"
+	kdfKeyFile = "keyfile"
+
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+
+	// first byte of a sealed value, plaintext json values written before the
+	// store was encrypted start with '{'
+	sealedVersion = byte(1)
+)
+
+// ErrStoreLocked is returned while the tss store has not been unlocked with
+// its passphrase or key file.
+var ErrStoreLocked = errors.New("tss store is locked, unlock it with the passphrase or key file")
+
+// ErrStoreNotInitialized is returned by an unlock of a tss store that has no
+// secret yet, InitStore gives the store its secret.
+var ErrStoreNotInitialized = errors.New("tss store is not initialized, run cipherd init")
+
+// StoreSecret unlocks the tss store, either a passphrase or a key file
+// holding 32 hex encoded bytes.
+type StoreSecret struct {
+	Passphrase string
+	KeyFile    string
+}
+
+// keyringRecord is the stored data key, wrapped with the key encryption key
+// derived from the secret.
+type keyringRecord struct {
+	KDF        string
+	Salt       []byte
+	N, R, P    int
+	WrappedKey []byte
+}
+
+// keyring seals the values of the tss store with envelope encryption. The
+// values are encrypted with a random data key, the data key is stored wrapped
+// with a key encryption key derived from the secret, so a rekey only rewraps
+// the data key.
+type keyring struct {
+	mtx   sync.RWMutex
+	store store.Store
+	//nil while the store is locked
+	dataKey cipher.AEAD
+}
+
+func newKeyring(st store.Store) *keyring {
+	return &keyring{store: st}
+}
+
+func (k *keyring) locked() bool {
+	k.mtx.RLock()
+	defer k.mtx.RUnlock()
+	return k.dataKey == nil
+}
+
+// initialize creates the data key of a new store and wraps it with secret.
+func (k *keyring) initialize(secret StoreSecret) error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	rec, err := k.loadRecord()
+	if err != nil {
+		return err
+	}
+	if rec != nil {
+		return errors.New("tss store is already initialized")
+	}
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return err
+	}
+	return k.saveRecord(secret, dataKey)
+}
+
+// unlock unwraps the data key with secret.
+func (k *keyring) unlock(secret StoreSecret) error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	rec, err := k.loadRecord()
+	if err != nil {
+		return err
+	}
+	if rec == nil {
+		return ErrStoreNotInitialized
+	}
+	dataKey, err := rec.unwrap(secret)
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+	k.dataKey = aead
+	return nil
+}
+
+// rekey wraps the data key with the key encryption key of newSecret.
+func (k *keyring) rekey(oldSecret, newSecret StoreSecret) error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	rec, err := k.loadRecord()
+	if err != nil {
+		return err
+	}
+	if rec == nil {
+		return ErrStoreNotInitialized
+	}
+	dataKey, err := rec.unwrap(oldSecret)
+	if err != nil {
+		return err
+	}
+	return k.saveRecord(newSecret, dataKey)
+}
+
+// seal encrypts value, the store key of the value is authenticated with it.
+func (k *keyring) seal(key, value []byte) ([]byte, error) {
+	k.mtx.RLock()
+	defer k.mtx.RUnlock()
+	if k.dataKey == nil {
+		return nil, ErrStoreLocked
+	}
+	nonce := make([]byte, k.dataKey.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := append([]byte{sealedVersion}, nonce...)
+	return k.dataKey.Seal(sealed, nonce, value, key), nil
+}
+
+// open decrypts a value sealed under key.
+func (k *keyring) open(key, sealed []byte) ([]byte, error) {
+	k.mtx.RLock()
+	defer k.mtx.RUnlock()
+	if k.dataKey == nil {
+		return nil, ErrStoreLocked
+	}
+	nonceSize := k.dataKey.NonceSize()
+	if !isSealed(sealed) || len(sealed) < 1+nonceSize {
+		return nil, errors.New("value is not sealed")
+	}
+	return k.dataKey.Open(nil, sealed[1:1+nonceSize], sealed[1+nonceSize:], key)
+}
+
+func isSealed(value []byte) bool {
+	return len(value) > 0 && value[0] == sealedVersion
+}
+
+func (k *keyring) loadRecord() (*keyringRecord, error) {
+	bz := k.store.Get([]byte(keyringKey))
+	if bz == nil {
+		return nil, nil
+	}
+	rec := new(keyringRecord)
+	if err := json.Unmarshal(bz, rec); err != nil {
+		return nil, fmt.Errorf("corrupt keyring: %v", err)
+	}
+	return rec, nil
+}
+
+func (k *keyring) saveRecord(secret StoreSecret, dataKey []byte) error {
+	rec, kek, err := newKeyringRecord(secret)
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	rec.WrappedKey = aead.Seal(nonce, nonce, dataKey, nil)
+	bz, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	k.store.Set([]byte(keyringKey), bz)
+	return nil
+}
+
+// newKeyringRecord returns a record for secret and its key encryption key.
+func newKeyringRecord(secret StoreSecret) (*keyringRecord, []byte, error) {
+	rec := &keyringRecord{}
+	switch {
+	case secret.Passphrase != "" && secret.KeyFile != "":
+		return nil, nil, errors.New("give either a passphrase or a key file")
+	case secret.Passphrase != "":
+		rec.KDF, rec.N, rec.R, rec.P = kdfScrypt, scryptN, scryptR, scryptP
+		rec.Salt = make([]byte, 32)
+		if _, err := rand.Read(rec.Salt); err != nil {
+			return nil, nil, err
+		}
+	case secret.KeyFile != "":
+		rec.KDF = kdfKeyFile
+	default:
+		return nil, nil, errors.New("no passphrase or key file")
+	}
+	kek, err := rec.kek(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rec, kek, nil
+}
+
+// kek derives the key encryption key from secret.
+func (rec *keyringRecord) kek(secret StoreSecret) ([]byte, error) {
+	switch rec.KDF {
+	case kdfScrypt:
+		if secret.Passphrase == "" {
+			return nil, errors.New("tss store is locked with a passphrase")
+		}
+		return scrypt.Key([]byte(secret.Passphrase), rec.Salt, rec.N, rec.R, rec.P, 32)
+	case kdfKeyFile:
+		if secret.KeyFile == "" {
+			return nil, errors.New("tss store is locked with a key file")
+		}
+		return readKeyFile(secret.KeyFile)
+	default:
+		return nil, fmt.Errorf("unknown kdf %s", rec.KDF)
+	}
+}
+
+func (rec *keyringRecord) unwrap(secret StoreSecret) ([]byte, error) {
+	kek, err := rec.kek(secret)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	nonceSize := aead.NonceSize()
+	if len(rec.WrappedKey) < nonceSize {
+		return nil, errors.New("corrupt keyring")
+	}
+	dataKey, err := aead.Open(nil, rec.WrappedKey[:nonceSize], rec.WrappedKey[nonceSize:], nil)
+	if err != nil {
+		return nil, errors.New("wrong passphrase or key file")
+	}
+	return dataKey, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func readKeyFile(path string) ([]byte, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(bz)))
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("key file %s must hold 32 hex encoded bytes", path)
+	}
+	return key, nil
+}
+
+// InitStore creates the data key of the tss store in storeDB and wraps it
+// with secret, to prepare the store of a node that is not running.
+func InitStore(storeDB dbm.DB, secret StoreSecret) error {
+	return newKeyring(store.NewStore(storeDB, []byte(storeKey))).initialize(secret)
+}
+
+// StoreInitialized reports whether the tss store in storeDB has its secret.
+func StoreInitialized(storeDB dbm.DB) (bool, error) {
+	rec, err := newKeyring(store.NewStore(storeDB, []byte(storeKey))).loadRecord()
+	return rec != nil, err
+}
+
+// GenerateKeyFile writes a new random key file for the tss store to path.
+func GenerateKeyFile(path string) error {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0600)
+}
@@ -0,0 +1,84 @@
+package threshold
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cfg "CipherMachine/config"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestKeyring(t *testing.T) {
+	db := dbm.NewMemDB()
+	tsr := NewTssReactor(cfg.TestConfig(), db, "a")
+	saveData := SaveData{
+		PartySaveData:  &PartySaveData{},
+		ConfigSaveData: &ConfigSaveData{LocalAddr: "a", Peers: []string{"a", "b", "c"}, Thresold: 1},
+	}
+
+	// a locked store neither writes nor reads shares
+	require.Equal(t, ErrStoreLocked, tsr.setSaveData("key", saveData))
+	res := tsr.Keygen(context.Background(), KeygenRequest{KeyID: "key", Threshold: 1})
+	require.Equal(t, ErrStoreLocked, res.Err.Cause())
+
+	// a store without a secret is not unlocked by the first secret given
+	passphrase := StoreSecret{Passphrase: "secret"}
+	require.Equal(t, ErrStoreNotInitialized, tsr.Unlock(passphrase))
+	initialized, err := StoreInitialized(db)
+	require.NoError(t, err)
+	require.False(t, initialized)
+	require.NoError(t, InitStore(db, passphrase))
+	require.Error(t, InitStore(db, StoreSecret{Passphrase: "other"}))
+	initialized, err = StoreInitialized(db)
+	require.NoError(t, err)
+	require.True(t, initialized)
+	require.NoError(t, tsr.Unlock(passphrase))
+	require.NoError(t, tsr.setSaveData("key", saveData))
+	stored := tsr.tssStore.Get(tsr.newPrefixKey(SaveDataKey, "key"))
+	require.NotContains(t, string(stored), `"LocalAddr"`)
+	got, err := tsr.getSaveData("key")
+	require.NoError(t, err)
+	require.Equal(t, saveData.ConfigSaveData, got.ConfigSaveData)
+
+	// a sealed share is bound to its key id
+	tsr.tssStore.Set(tsr.newPrefixKey(SaveDataKey, "other"), stored)
+	_, err = tsr.getSaveData("other")
+	require.Error(t, err)
+
+	// a share written before the store was encrypted is sealed on first use
+	plain, err := json.Marshal(saveData)
+	require.NoError(t, err)
+	tsr.tssStore.Set(tsr.newPrefixKey(SaveDataKey, "legacy"), plain)
+	got, err = tsr.getSaveData("legacy")
+	require.NoError(t, err)
+	require.Equal(t, saveData.ConfigSaveData, got.ConfigSaveData)
+	require.True(t, isSealed(tsr.tssStore.Get(tsr.newPrefixKey(SaveDataKey, "legacy"))))
+
+	// after a restart only the right secret unlocks the store
+	tsr = NewTssReactor(cfg.TestConfig(), db, "a")
+	_, err = tsr.getSaveData("key")
+	require.Equal(t, ErrStoreLocked, err)
+	require.Error(t, tsr.Unlock(StoreSecret{Passphrase: "wrong"}))
+	require.True(t, tsr.Locked())
+
+	// rekey to a key file, the shares stay readable
+	dir, err := ioutil.TempDir("", "keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keyFile := StoreSecret{KeyFile: filepath.Join(dir, "tss.key")}
+	require.NoError(t, GenerateKeyFile(keyFile.KeyFile))
+	require.Error(t, tsr.Rekey(StoreSecret{Passphrase: "wrong"}, keyFile))
+	require.NoError(t, tsr.Rekey(passphrase, keyFile))
+
+	tsr = NewTssReactor(cfg.TestConfig(), db, "a")
+	require.Error(t, tsr.Unlock(passphrase))
+	require.NoError(t, tsr.Unlock(keyFile))
+	got, err = tsr.getSaveData("key")
+	require.NoError(t, err)
+	require.Equal(t, saveData.ConfigSaveData, got.ConfigSaveData)
+}
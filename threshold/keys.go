@@ -0,0 +1,209 @@
+package threshold
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"CipherMachine/tsslib/tss"
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+const keyInfoKey = "KeyInfo"
+
+// KeyInfo describes a key the node holds a share of. It holds no secrets and
+// is stored unencrypted, so the keys can be audited while the store is
+// locked. Created is when the node stored the share, by keygen, resharing or
+// import, LastUsed when it last took part in a signing with it.
+type KeyInfo struct {
+	KeyID   KeyID
+	KeyType KeyType
+	Curve   tss.CurveName
+	//compressed public key, the RFC 8032 encoding for an EdDSA key
+	PubKey    cmn.HexBytes
+	Threshold int
+	Committee []string
+	Created   time.Time
+	LastUsed  time.Time
+	//a retired key refuses signing
+	Retired bool
+}
+
+// ListKeys returns the keys the node holds, ordered by key id. Keys stored
+// before the inventory existed are described on first listing, while the
+// store is locked only their id is known.
+func (tsr *TssReactor) ListKeys() ([]*KeyInfo, error) {
+	var keyIDs []KeyID
+	it := tsr.tssStore.Iterator([]byte(SaveDataKey))
+	for ; it.Valid(); it.Next() {
+		keyIDs = append(keyIDs, KeyID(it.Key()[len(SaveDataKey):]))
+	}
+	it.Close()
+
+	infos := make([]*KeyInfo, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		info, err := tsr.KeyInfo(keyID)
+		if err == ErrStoreLocked {
+			info = &KeyInfo{KeyID: keyID}
+		} else if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// KeyInfo describes the key of keyID.
+func (tsr *TssReactor) KeyInfo(keyID KeyID) (*KeyInfo, error) {
+	tsr.keysMtx.Lock()
+	defer tsr.keysMtx.Unlock()
+	if !tsr.tssStore.Has(tsr.newPrefixKey(SaveDataKey, keyID)) {
+		return nil, fmt.Errorf("no key %s", keyID)
+	}
+	info, err := tsr.loadKeyInfo(keyID)
+	if err != nil || info != nil {
+		return info, err
+	}
+	if tsr.keyring.locked() {
+		return nil, ErrStoreLocked
+	}
+	saveData, err := tsr.getSaveData(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return tsr.storeKeyInfo(keyID, saveData, time.Time{})
+}
+
+// RetireKey marks the key of keyID retired, the node refuses to sign with it
+// from now on. The share is kept until the key is deleted.
+func (tsr *TssReactor) RetireKey(keyID KeyID) error {
+	if _, err := tsr.KeyInfo(keyID); err != nil {
+		return err
+	}
+	tsr.keysMtx.Lock()
+	defer tsr.keysMtx.Unlock()
+	info, err := tsr.loadKeyInfo(keyID)
+	if err != nil {
+		return err
+	}
+	info.Retired = true
+	if err := tsr.setKeyInfo(info); err != nil {
+		return err
+	}
+	tsr.Logger.Info("key retired", "key", keyID)
+	return nil
+}
+
+// DeleteKey deletes the share of keyID for good. Only a retired key can be
+// deleted, and confirm has to be its public key in hex as listed.
+func (tsr *TssReactor) DeleteKey(keyID KeyID, confirm string) error {
+	info, err := tsr.KeyInfo(keyID)
+	if err != nil {
+		return err
+	}
+	if !info.Retired {
+		return fmt.Errorf("key %s is not retired, retire it before deleting it", keyID)
+	}
+	if pubKey, err := hex.DecodeString(confirm); err != nil || len(pubKey) == 0 || !bytes.Equal(pubKey, info.PubKey) {
+		return fmt.Errorf("deleting key %s needs its public key as confirmation", keyID)
+	}
+	if tsr.sessions.keyBusy(keyID) {
+		return fmt.Errorf("key %s is in use", keyID)
+	}
+	tsr.deleteKey(keyID)
+	tsr.Logger.Info("key deleted", "key", keyID, "pubkey", info.PubKey)
+	return nil
+}
+
+// deleteKey removes the share of keyID, its info and its presignatures. The
+// presignatures are dropped after keysMtx is released, takePresignatures
+// takes presigMtx before keysMtx.
+func (tsr *TssReactor) deleteKey(keyID KeyID) {
+	tsr.keysMtx.Lock()
+	tsr.tssStore.Delete(tsr.newPrefixKey(SaveDataKey, keyID))
+	tsr.tssStore.Delete(tsr.newPrefixKey(keyInfoKey, keyID))
+	tsr.keysMtx.Unlock()
+	tsr.dropPresignatures(keyID)
+}
+
+// checkNotRetired returns an error if keyID is retired.
+func (tsr *TssReactor) checkNotRetired(keyID KeyID) error {
+	tsr.keysMtx.Lock()
+	defer tsr.keysMtx.Unlock()
+	info, err := tsr.loadKeyInfo(keyID)
+	if err != nil {
+		return err
+	}
+	if info != nil && info.Retired {
+		return fmt.Errorf("key %s is retired", keyID)
+	}
+	return nil
+}
+
+// updateKeyInfo describes the share just stored for keyID. The creation time,
+// last use and retirement of a key the node held before are kept.
+func (tsr *TssReactor) updateKeyInfo(keyID KeyID, saveData SaveData) {
+	tsr.keysMtx.Lock()
+	defer tsr.keysMtx.Unlock()
+	if _, err := tsr.storeKeyInfo(keyID, saveData, time.Now().UTC()); err != nil {
+		tsr.Logger.Error("store key info failed", "key", keyID, "err", err)
+	}
+}
+
+// touchKey records a signing with keyID.
+func (tsr *TssReactor) touchKey(keyID KeyID) {
+	tsr.keysMtx.Lock()
+	defer tsr.keysMtx.Unlock()
+	info, err := tsr.loadKeyInfo(keyID)
+	if err == nil && info != nil {
+		info.LastUsed = time.Now().UTC()
+		err = tsr.setKeyInfo(info)
+	}
+	if err != nil {
+		tsr.Logger.Error("store key info failed", "key", keyID, "err", err)
+	}
+}
+
+// storeKeyInfo must be called with keysMtx held.
+func (tsr *TssReactor) storeKeyInfo(keyID KeyID, saveData SaveData, created time.Time) (*KeyInfo, error) {
+	if saveData.PartySaveData == nil || saveData.ConfigSaveData == nil || saveData.PartySaveData.pubKey() == nil {
+		return nil, errors.New("incomplete save data")
+	}
+	info, err := tsr.loadKeyInfo(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		info = &KeyInfo{KeyID: keyID, Created: created}
+	}
+	info.KeyType = saveData.PartySaveData.keyType()
+	info.Curve = curveName(saveData.PartySaveData.share().ec)
+	info.PubKey = encodePubKey(info.KeyType, saveData.PartySaveData.pubKey())
+	info.Threshold = saveData.ConfigSaveData.Thresold
+	info.Committee = saveData.ConfigSaveData.Peers
+	return info, tsr.setKeyInfo(info)
+}
+
+func (tsr *TssReactor) loadKeyInfo(keyID KeyID) (*KeyInfo, error) {
+	bz := tsr.tssStore.Get(tsr.newPrefixKey(keyInfoKey, keyID))
+	if bz == nil {
+		return nil, nil
+	}
+	info := new(KeyInfo)
+	if err := json.Unmarshal(bz, info); err != nil {
+		return nil, fmt.Errorf("corrupt info of key %s: %v", keyID, err)
+	}
+	return info, nil
+}
+
+func (tsr *TssReactor) setKeyInfo(info *KeyInfo) error {
+	bz, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	tsr.tssStore.Set(tsr.newPrefixKey(keyInfoKey, info.KeyID), bz)
+	return nil
+}
@@ -0,0 +1,72 @@
+package threshold
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	cfg "CipherMachine/config"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestKeyInventory(t *testing.T) {
+	db := dbm.NewMemDB()
+	require.NoError(t, InitStore(db, StoreSecret{Passphrase: "test"}))
+	tsr := NewTssReactor(cfg.TestConfig(), db, "aa")
+	tsr.peers = []string{"aa", "bb"}
+	require.NoError(t, tsr.Unlock(StoreSecret{Passphrase: "test"}))
+
+	saveData := loadTestSaveData(t, 0)
+	pubKey := saveData.PartySaveData.LocalPartySaveData.ECDSAPub.SerializeCompressed()
+	// a key stored before the inventory, and one stored with its info
+	require.NoError(t, tsr.setSaveData("a-legacy", saveData))
+	require.NoError(t, tsr.setSaveData("b-key", saveData))
+	tsr.updateKeyInfo("b-key", saveData)
+	tsr.touchKey("b-key")
+
+	// while locked the legacy key is only known by its id
+	locked := NewTssReactor(cfg.TestConfig(), db, "aa")
+	infos, err := locked.ListKeys()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, &KeyInfo{KeyID: "a-legacy"}, infos[0])
+	require.Equal(t, KeyID("b-key"), infos[1].KeyID)
+
+	infos, err = tsr.ListKeys()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	for _, info := range infos {
+		require.Equal(t, []byte(pubKey), []byte(info.PubKey))
+		require.Equal(t, 1, info.Threshold)
+		require.Equal(t, []string{"aa", "bb"}, info.Committee)
+		require.False(t, info.Retired)
+	}
+	require.True(t, infos[0].Created.IsZero())
+	require.True(t, infos[0].LastUsed.IsZero())
+	require.False(t, infos[1].Created.IsZero())
+	require.False(t, infos[1].LastUsed.IsZero())
+
+	// a key is retired before it can be deleted
+	confirm := hex.EncodeToString(pubKey)
+	require.Error(t, tsr.DeleteKey("b-key", confirm))
+	require.NoError(t, tsr.RetireKey("b-key"))
+	_, err = tsr.signing(context.Background(), []byte{1}, "b-key", newSessionID(), nil, nil, nil)
+	require.EqualError(t, err, "key b-key is retired")
+	info, err := tsr.KeyInfo("b-key")
+	require.NoError(t, err)
+	require.True(t, info.Retired)
+	require.False(t, info.LastUsed.IsZero())
+
+	require.Error(t, tsr.DeleteKey("b-key", ""))
+	require.Error(t, tsr.DeleteKey("b-key", hex.EncodeToString(pubKey[1:])))
+	require.NoError(t, tsr.DeleteKey("b-key", confirm))
+	_, err = tsr.getSaveData("b-key")
+	require.Error(t, err)
+	_, err = tsr.KeyInfo("b-key")
+	require.Error(t, err)
+	infos, err = tsr.ListKeys()
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, KeyID("a-legacy"), infos[0].KeyID)
+}
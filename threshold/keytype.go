@@ -0,0 +1,162 @@
+package threshold
+
+import (
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	frostsigning "CipherMachine/tsslib/frost/signing"
+	"CipherMachine/tsslib/tss"
+)
+
+// KeyType names the signature scheme of a key, chosen at keygen.
+type KeyType string
+
+const (
+	// KeyTypeECDSA is a secp256k1 ECDSA key, the type of keys generated
+	// before the key type was chosen
+	KeyTypeECDSA KeyType = "ecdsa"
+	// KeyTypeEdDSA is an Ed25519 key
+	KeyTypeEdDSA KeyType = "eddsa"
+	// KeyTypeSchnorr is a secp256k1 key signing BIP340 Schnorr signatures
+	// with FROST, as taproot outputs take
+	KeyTypeSchnorr KeyType = "schnorr"
+)
+
+// normalized returns kt, ECDSA for the empty key type of keys and msgs from
+// before EdDSA.
+func (kt KeyType) normalized() KeyType {
+	if kt == "" {
+		return KeyTypeECDSA
+	}
+	return kt
+}
+
+func (kt KeyType) validate() error {
+	switch kt.normalized() {
+	case KeyTypeECDSA, KeyTypeEdDSA, KeyTypeSchnorr:
+		return nil
+	}
+	return fmt.Errorf("unknown key type %q", kt)
+}
+
+// curve returns the curve of a new key of kt on the curve of name, the
+// default curve of the key type if name is empty. ECDSA keys are on
+// secp256k1 or P-256, the default curve of tss.SetCurve by default, EdDSA
+// keys on Ed25519 and Schnorr keys on secp256k1.
+func (kt KeyType) curve(name tss.CurveName) (elliptic.Curve, error) {
+	switch kt.normalized() {
+	case KeyTypeECDSA:
+		switch name {
+		case "":
+			return tss.EC(), nil
+		case tss.Secp256k1, tss.P256:
+			ec, _ := tss.GetCurveByName(name)
+			return ec, nil
+		}
+	case KeyTypeEdDSA:
+		if name == "" || name == tss.Ed25519 {
+			return tss.Edwards(), nil
+		}
+	case KeyTypeSchnorr:
+		if name == "" || name == tss.Secp256k1 {
+			return tss.S256(), nil
+		}
+	}
+	return nil, fmt.Errorf("no %s keys on curve %q", kt.normalized(), name)
+}
+
+// curveName returns the name of ec, empty if it is not registered.
+func curveName(ec elliptic.Curve) tss.CurveName {
+	name, _ := tss.GetCurveName(ec)
+	return name
+}
+
+// share is the part of a share common to the key types.
+type share struct {
+	ec      elliptic.Curve
+	Xi      *big.Int
+	ShareID *big.Int
+	Ks      []*big.Int
+	BigXj   []*crypto.ECPoint
+	PubKey  *crypto.ECPoint
+}
+
+func (psd *PartySaveData) keyType() KeyType {
+	return psd.KeyType.normalized()
+}
+
+// share returns the share of psd, nil for an EdDSA or Schnorr key without its
+// save data.
+func (psd *PartySaveData) share() *share {
+	switch psd.keyType() {
+	case KeyTypeEdDSA:
+		key := psd.EdDSASaveData
+		if key == nil {
+			return nil
+		}
+		return &share{ec: tss.Edwards(), Xi: key.Xi, ShareID: key.ShareID, Ks: key.Ks, BigXj: key.BigXj, PubKey: key.EDDSAPub}
+	case KeyTypeSchnorr:
+		key := psd.SchnorrSaveData
+		if key == nil {
+			return nil
+		}
+		return &share{ec: tss.S256(), Xi: key.Xi, ShareID: key.ShareID, Ks: key.Ks, BigXj: key.BigXj, PubKey: key.SchnorrPub}
+	}
+	// the public key records the curve of the key
+	key := psd.LocalPartySaveData
+	ec := tss.EC()
+	if key.ECDSAPub != nil {
+		ec = key.ECDSAPub.Curve()
+	}
+	return &share{ec: ec, Xi: key.Xi, ShareID: key.ShareID, Ks: key.Ks, BigXj: key.BigXj, PubKey: key.ECDSAPub}
+}
+
+// pubKey returns the public key of psd, nil if the share is incomplete.
+func (psd *PartySaveData) pubKey() *crypto.ECPoint {
+	if s := psd.share(); s != nil {
+		return s.PubKey
+	}
+	return nil
+}
+
+// encodePubKey returns the encoding of pub used by the chains of keyType,
+// compressed for ECDSA, RFC 8032 for EdDSA and the BIP340 x-only key for
+// Schnorr.
+func encodePubKey(keyType KeyType, pub *crypto.ECPoint) []byte {
+	switch keyType.normalized() {
+	case KeyTypeEdDSA:
+		return pub.SerializeEd25519()
+	case KeyTypeSchnorr:
+		return pub.SerializeXOnly()
+	}
+	return pub.SerializeCompressed()
+}
+
+// verifyEdDSA checks the Ed25519 signature of msg by pub.
+func verifyEdDSA(pub *crypto.ECPoint, msg []byte, signature common.SignatureData) error {
+	if len(signature.R) != 32 || len(signature.S) != 32 {
+		return errors.New("invalid EdDSA signature")
+	}
+	sig := append(append(make([]byte, 0, ed25519.SignatureSize), signature.R...), signature.S...)
+	if !ed25519.Verify(pub.SerializeEd25519(), msg, sig) {
+		return errors.New("EdDSA verify failed")
+	}
+	return nil
+}
+
+// verifySchnorr checks the BIP340 signature of msg by pub.
+func verifySchnorr(pub *crypto.ECPoint, msg []byte, signature common.SignatureData) error {
+	if len(signature.R) != 32 || len(signature.S) != 32 {
+		return errors.New("invalid Schnorr signature")
+	}
+	sig := append(append(make([]byte, 0, 64), signature.R...), signature.S...)
+	if !frostsigning.Verify(pub.SerializeXOnly(), msg, sig) {
+		return errors.New("Schnorr verify failed")
+	}
+	return nil
+}
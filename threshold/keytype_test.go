@@ -0,0 +1,197 @@
+package threshold
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	frostsigning "CipherMachine/tsslib/frost/signing"
+	"CipherMachine/tsslib/tss"
+)
+
+// an Ed25519 key is generated, signs messages as they are, survives a backup
+// and is reshared by the same nodes as the ECDSA keys
+func TestEdDSAKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	reactors := makeTestReactors(t, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	res := reactors[0].Keygen(ctx, KeygenRequest{KeyID: "ed-key", KeyType: KeyTypeEdDSA, Threshold: 1})
+	require.Nil(t, res.Err)
+	require.Equal(t, KeyTypeEdDSA, res.KeyType)
+	waitForShares(t, reactors, "ed-key")
+	pubKey := ed25519.PublicKey(res.PubKey.SerializeEd25519())
+	for _, r := range reactors {
+		info, err := r.KeyInfo("ed-key")
+		require.NoError(t, err)
+		require.Equal(t, KeyTypeEdDSA, info.KeyType)
+		require.Equal(t, []byte(pubKey), []byte(info.PubKey))
+	}
+
+	data := []byte("a message longer than a 32 byte digest, signed as it is")
+	sres := reactors[1].SignMessage(ctx, "ed-key", data, HashNone)
+	require.Nil(t, sres.Err)
+	require.Equal(t, data, sres.Signature.M)
+	sig := append(append([]byte{}, sres.Signature.R...), sres.Signature.S...)
+	require.True(t, ed25519.Verify(pubKey, data, sig))
+	for _, r := range reactors {
+		require.NoError(t, r.VerifyMessage("ed-key", data, HashNone, *sres.Signature))
+	}
+	require.Error(t, reactors[0].VerifyMessage("ed-key", data[1:], HashNone, *sres.Signature))
+
+	// a digest is signed as the message
+	sres = reactors[2].SignMessage(ctx, "ed-key", data, HashSHA256)
+	require.Nil(t, sres.Err)
+	digest, err := HashMessage(data, HashSHA256)
+	require.NoError(t, err)
+	require.NoError(t, reactors[0].VerifyDigest("ed-key", digest, *sres.Signature))
+
+	// EdDSA keys have no BIP32 children
+	sres = reactors[0].Sign(ctx, SignRequest{KeyID: "ed-key", Data: data, Path: []uint32{1}})
+	require.NotNil(t, sres.Err)
+	_, err = reactors[0].DerivePubKey("ed-key", []uint32{1})
+	require.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "share.json")
+	require.NoError(t, reactors[0].ExportShare("ed-key", path, "backup"))
+	reactors[0].deleteKey("ed-key")
+	keyID, err := reactors[0].ImportShare(path, "backup")
+	require.NoError(t, err)
+	require.Equal(t, KeyID("ed-key"), keyID)
+
+	peers := reactors[0].peers
+	resCh, err := reactors[0].Resharing("ed-key", peers, 2)
+	require.NoError(t, err)
+	select {
+	case rres := <-resCh:
+		require.Nil(t, rres.Err)
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	for _, r := range reactors {
+		require.Eventually(t, func() bool {
+			info, err := r.KeyInfo("ed-key")
+			return err == nil && info.Threshold == 2
+		}, 30*time.Second, 100*time.Millisecond)
+	}
+	sres = reactors[2].Sign(ctx, SignRequest{KeyID: "ed-key", Data: data})
+	require.Nil(t, sres.Err)
+	require.True(t, ed25519.Verify(pubKey, data, append(append([]byte{}, sres.Signature.R...), sres.Signature.S...)))
+}
+
+// a Schnorr key signs BIP340 signatures, for itself and for the key path of
+// its taproot outputs
+func TestSchnorrKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	reactors := makeTestReactors(t, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	res := reactors[0].Keygen(ctx, KeygenRequest{KeyID: "schnorr-key", KeyType: KeyTypeSchnorr, Threshold: 1})
+	require.Nil(t, res.Err)
+	require.Equal(t, KeyTypeSchnorr, res.KeyType)
+	waitForShares(t, reactors, "schnorr-key")
+	pubKey := res.PubKey.SerializeXOnly()
+	for _, r := range reactors {
+		info, err := r.KeyInfo("schnorr-key")
+		require.NoError(t, err)
+		require.Equal(t, KeyTypeSchnorr, info.KeyType)
+		require.Equal(t, pubKey, []byte(info.PubKey))
+	}
+
+	data := []byte("a taproot spend")
+	sres := reactors[1].SignMessage(ctx, "schnorr-key", data, HashSHA256)
+	require.Nil(t, sres.Err)
+	digest, err := HashMessage(data, HashSHA256)
+	require.NoError(t, err)
+	require.True(t, frostsigning.Verify(pubKey, digest[:], sres.Signature.Signature))
+	for _, r := range reactors {
+		require.NoError(t, r.VerifyMessage("schnorr-key", data, HashSHA256, *sres.Signature))
+	}
+	require.Error(t, reactors[0].VerifyMessage("schnorr-key", data[1:], HashSHA256, *sres.Signature))
+
+	// the key path spend of taproot outputs with and without a script tree
+	merkleRoot := sha256.Sum256([]byte("script tree"))
+	for _, root := range [][]byte{nil, merkleRoot[:]} {
+		Q, err := reactors[0].TaprootOutputKey("schnorr-key", root)
+		require.NoError(t, err)
+		sres = reactors[2].Sign(ctx, SignRequest{KeyID: "schnorr-key", Data: digest[:], Taproot: &TaprootTweak{MerkleRoot: root}})
+		require.Nil(t, sres.Err)
+		require.True(t, frostsigning.Verify(Q.SerializeXOnly(), digest[:], sres.Signature.Signature))
+		require.False(t, frostsigning.Verify(pubKey, digest[:], sres.Signature.Signature))
+	}
+
+	// Schnorr keys have no BIP32 children and are not reshared
+	sres = reactors[0].Sign(ctx, SignRequest{KeyID: "schnorr-key", Data: digest[:], Path: []uint32{1}})
+	require.NotNil(t, sres.Err)
+	_, err = reactors[0].ChainCode("schnorr-key")
+	require.Error(t, err)
+	_, err = reactors[0].Resharing("schnorr-key", reactors[0].peers, 2)
+	require.Error(t, err)
+}
+
+// the same nodes hold a secp256k1 and a P-256 ECDSA key and sign with both
+func TestP256Key(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	reactors := makeTestReactors(t, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	_, err := reactors[0].keygen(ctx, KeyTypeEdDSA, tss.P256, 1, "bad-key", newSessionID())
+	require.Error(t, err)
+
+	for _, c := range []struct {
+		keyID KeyID
+		curve tss.CurveName
+		ec    elliptic.Curve
+	}{
+		{"k1-key", tss.Secp256k1, tss.S256()},
+		{"p256-key", tss.P256, elliptic.P256()},
+	} {
+		res := reactors[0].Keygen(ctx, KeygenRequest{KeyID: c.keyID, Curve: c.curve, Threshold: 1})
+		require.Nil(t, res.Err)
+		require.Equal(t, c.curve, res.Curve)
+		require.Equal(t, c.ec, res.PubKey.Curve())
+		waitForShares(t, reactors, c.keyID)
+		for _, r := range reactors {
+			info, err := r.KeyInfo(c.keyID)
+			require.NoError(t, err)
+			require.Equal(t, c.curve, info.Curve)
+		}
+
+		// the key is read back on its curve
+		saveData, err := reactors[1].getSaveData(c.keyID)
+		require.NoError(t, err)
+		require.Equal(t, c.ec, saveData.PartySaveData.LocalPartySaveData.ECDSAPub.Curve())
+
+		path := []uint32{7}
+		child, err := reactors[1].DerivePubKey(c.keyID, path)
+		require.NoError(t, err)
+		msg := big.NewInt(42)
+		for _, p := range [][]uint32{nil, path} {
+			sres := reactors[2].Sign(ctx, SignRequest{KeyID: c.keyID, Msg: msg, Path: p})
+			require.Nil(t, sres.Err)
+			pub := res.PubKey
+			if p != nil {
+				pub = child
+			}
+			r, s := new(big.Int).SetBytes(sres.Signature.R), new(big.Int).SetBytes(sres.Signature.S)
+			require.True(t, ecdsa.Verify(&ecdsa.PublicKey{Curve: c.ec, X: pub.X(), Y: pub.Y()}, msg.Bytes(), r, s))
+		}
+	}
+}
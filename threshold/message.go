@@ -3,11 +3,13 @@ package threshold
 import (
 	"CipherMachine/tsslib/tss"
 	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto"
 )
 
 type msgType string
 
 type TssMessage struct {
+	KeyID KeyID
 	Sid SessionID
 	Threshold int
 	From *tss.PartyID
@@ -15,6 +17,35 @@ type TssMessage struct {
 	Msg []byte
 	Pmsg []byte
 	PmsgType msgType
+	//keygen and resharing only, the type and the curve of the key, empty for
+	//an ECDSA key on the default curve
+	KeyType KeyType
+	Curve tss.CurveName
+
+	//signing only, node ids of the signing quorum, the BIP32 path of the
+	//child key that signs and the taproot output a Schnorr key signs for.
+	//A presigning has a signing quorum too
+	Parties []string
+	Path []uint32
+	Taproot *TaprootTweak
+	//batch signing only, the digests of the batch and the ids of the
+	//presignatures that sign them, none if the session makes them
+	Msgs [][]byte
+	Presigs []string
+
+	//presigning only, the number of presignatures of the session
+	Count int
+
+	//resharing only
+	OldParties tss.SortedPartyIDs
+	NewParties tss.SortedPartyIDs
+	NewThreshold int
+	ToOldCommittee bool
+	ChainCode []byte
+
+	//signed msgs only, the node key of the sender and its signature of the msg
+	PubKey crypto.PubKey
+	Signature []byte
 }
 
 func RegisterTssMessages(cdc *amino.Codec) {
@@ -0,0 +1,192 @@
+package threshold
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"CipherMachine/store"
+	"CipherMachine/tsslib/ecdsa/keygen"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	preParamsKey     = "PreParams"
+	preParamsHeadKey = "PreParamsHead"
+	preParamsTailKey = "PreParamsTail"
+
+	// generating the safe primes may take long on a busy node, the pool is
+	// filled in the background anyway
+	preParamsGenTimeout = 30 * time.Minute
+)
+
+// preParamsPool keeps keygen pre-params generated ahead of time. The entries
+// are a queue in the store between head and tail, sealed like the shares.
+// Every entry is deleted when it is taken, so it is used by one keygen only,
+// also across restarts.
+type preParamsPool struct {
+	mtx        sync.Mutex
+	store      store.Store
+	keyring    *keyring
+	head, tail uint64
+	//number of entries the pool routine keeps
+	size int
+	//wakes the pool routine after an entry was taken
+	notify chan struct{}
+}
+
+func newPreParamsPool(st store.Store, kr *keyring, size int) *preParamsPool {
+	return &preParamsPool{
+		store:   st,
+		keyring: kr,
+		head:    getUint64(st, preParamsHeadKey),
+		tail:    getUint64(st, preParamsTailKey),
+		size:    size,
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// len returns the number of entries in the pool.
+func (p *preParamsPool) len() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return int(p.tail - p.head)
+}
+
+func (p *preParamsPool) put(preParams *keygen.LocalPreParams) error {
+	bz, err := json.Marshal(preParams)
+	if err != nil {
+		return err
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	key := preParamsEntryKey(p.tail)
+	sealed, err := p.keyring.seal(key, bz)
+	if err != nil {
+		return err
+	}
+	p.store.Set(key, sealed)
+	p.tail++
+	setUint64(p.store, preParamsTailKey, p.tail)
+	return nil
+}
+
+// take removes the oldest entry from the pool, it returns nil if the pool is
+// empty or the store is locked. An entry that cannot be read is dropped all
+// the same.
+func (p *preParamsPool) take() (*keygen.LocalPreParams, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.keyring.locked() || p.head == p.tail {
+		return nil, nil
+	}
+	i := p.head
+	key := preParamsEntryKey(i)
+	bz := p.store.Get(key)
+	p.store.Delete(key)
+	p.head++
+	setUint64(p.store, preParamsHeadKey, p.head)
+	p.wake()
+
+	preParams, err := p.open(key, bz)
+	if err != nil {
+		return nil, fmt.Errorf("pre-params pool entry %d: %v", i, err)
+	}
+	return preParams, nil
+}
+
+func (p *preParamsPool) open(key, bz []byte) (*keygen.LocalPreParams, error) {
+	plain, err := p.keyring.open(key, bz)
+	if err != nil {
+		return nil, err
+	}
+	preParams := new(keygen.LocalPreParams)
+	if err := json.Unmarshal(plain, preParams); err != nil {
+		return nil, err
+	}
+	if !preParams.ValidateWithProof() {
+		return nil, errors.New("invalid pre-params")
+	}
+	return preParams, nil
+}
+
+// wake wakes the pool routine.
+func (p *preParamsPool) wake() {
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+// preParamsRoutine fills the pool up to its size until the reactor stops. It
+// waits for the store to be unlocked.
+func (tsr *TssReactor) preParamsRoutine() {
+	pool := tsr.preParamsPool
+	for {
+		for !pool.keyring.locked() && pool.len() < pool.size {
+			start := time.Now()
+			preParams, err := keygen.GeneratePreParams(preParamsGenTimeout)
+			if !tsr.IsRunning() {
+				return
+			}
+			if err != nil {
+				tsr.Logger.Error("generate pre-params failed", "err", err)
+				continue
+			}
+			if err := pool.put(preParams); err != nil {
+				tsr.Logger.Error("store pre-params failed", "err", err)
+				return
+			}
+			tsr.Logger.Info("pre-params generated", "took", time.Since(start), "pool", pool.len())
+		}
+		select {
+		case <-pool.notify:
+		case <-tsr.Quit():
+			return
+		}
+	}
+}
+
+// AddPreParams puts preParams into the pre-params pool of the tss store in
+// storeDB, unlocking it with secret, to prepare the store of a node that is
+// not running.
+func AddPreParams(storeDB dbm.DB, secret StoreSecret, preParams ...*keygen.LocalPreParams) error {
+	st := store.NewStore(storeDB, []byte(storeKey))
+	kr := newKeyring(st)
+	if err := kr.unlock(secret); err != nil {
+		return err
+	}
+	pool := newPreParamsPool(st, kr, 0)
+	for _, p := range preParams {
+		if err := pool.put(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PreParamsPoolSize returns the number of keygen pre-params ready for use.
+func (tsr *TssReactor) PreParamsPoolSize() int {
+	return tsr.preParamsPool.len()
+}
+
+func preParamsEntryKey(i uint64) []byte {
+	return []byte(fmt.Sprintf("%s/%020d", preParamsKey, i))
+}
+
+func getUint64(st store.Store, key string) uint64 {
+	bz := st.Get([]byte(key))
+	if len(bz) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func setUint64(st store.Store, key string, v uint64) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, v)
+	st.Set([]byte(key), bz)
+}
@@ -0,0 +1,64 @@
+package threshold
+
+import (
+	"testing"
+
+	"CipherMachine/store"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestPreParamsPool(t *testing.T) {
+	db := dbm.NewMemDB()
+	st := store.NewStore(db, []byte(storeKey))
+	secret := StoreSecret{Passphrase: "secret"}
+	pool := newPreParamsPool(st, newKeyring(st), 2)
+
+	// the store is locked, nothing to hand out
+	require.Equal(t, ErrStoreLocked, pool.put(loadTestPreParams(t, 0)))
+	preParams, err := pool.take()
+	require.NoError(t, err)
+	require.Nil(t, preParams)
+
+	require.NoError(t, pool.keyring.initialize(secret))
+	require.NoError(t, pool.keyring.unlock(secret))
+	require.NoError(t, pool.put(loadTestPreParams(t, 0)))
+	require.NoError(t, pool.put(loadTestPreParams(t, 1)))
+	require.Equal(t, 2, pool.len())
+
+	// entries are taken in order, each once
+	preParams, err = pool.take()
+	require.NoError(t, err)
+	require.Equal(t, loadTestPreParams(t, 0).NTildei, preParams.NTildei)
+	require.Equal(t, 1, pool.len())
+
+	// the pool is kept across restarts
+	pool = newPreParamsPool(st, newKeyring(st), 2)
+	require.Equal(t, 1, pool.len())
+	require.NoError(t, pool.keyring.unlock(secret))
+	preParams, err = pool.take()
+	require.NoError(t, err)
+	require.Equal(t, loadTestPreParams(t, 1).NTildei, preParams.NTildei)
+	preParams, err = pool.take()
+	require.NoError(t, err)
+	require.Nil(t, preParams)
+}
+
+// pre-params added to the store of a node that is not running are taken by
+// its first keygens
+func TestAddPreParams(t *testing.T) {
+	db := dbm.NewMemDB()
+	secret := StoreSecret{Passphrase: "secret"}
+	require.Equal(t, ErrStoreNotInitialized, AddPreParams(db, secret, loadTestPreParams(t, 0)))
+	require.NoError(t, InitStore(db, secret))
+	require.NoError(t, AddPreParams(db, secret, loadTestPreParams(t, 0)))
+	require.Error(t, AddPreParams(db, StoreSecret{Passphrase: "wrong"}, loadTestPreParams(t, 1)))
+
+	st := store.NewStore(db, []byte(storeKey))
+	pool := newPreParamsPool(st, newKeyring(st), 2)
+	require.Equal(t, 1, pool.len())
+	require.NoError(t, pool.keyring.unlock(secret))
+	preParams, err := pool.take()
+	require.NoError(t, err)
+	require.Equal(t, loadTestPreParams(t, 0).NTildei, preParams.NTildei)
+}
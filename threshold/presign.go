@@ -0,0 +1,681 @@
+package threshold
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"CipherMachine/p2p"
+	"CipherMachine/tsslib/ecdsa/presign"
+	"CipherMachine/tsslib/tss"
+)
+
+// errPresigWithPath refuses presignatures for a child key. Their R is known
+// before the tweak is chosen, which allows related key forgeries.
+var errPresigWithPath = errors.New("a child key does not sign with presignatures")
+
+const (
+	presigKey  = "Presig"
+	PresignMsg = "presignMsg"
+
+	// MaxBatchSize is the largest number of digests a batch signing signs, or
+	// of presignatures a presigning makes. The msgs of a session carry the
+	// proofs of all of them.
+	MaxBatchSize = 64
+
+	// the pool routine checks the presignatures of the keys this often
+	presignPoolInterval = 30 * time.Second
+)
+
+// storedPresignature is a presignature in the store, with the signing parties
+// it was made for. The first of the parties owns it.
+type storedPresignature struct {
+	Presignature presign.Presignature
+	Parties      []string
+}
+
+type presignChannels struct {
+	errCh    chan *tss.Error
+	outCh    chan tss.Message
+	endCh    chan []presign.Presignature
+	sigEndCh chan []presign.Signature
+}
+
+func newPresignChannels(n int) presignChannels {
+	return presignChannels{
+		errCh:    make(chan *tss.Error, 1),
+		outCh:    make(chan tss.Message, 2*n),
+		endCh:    make(chan []presign.Presignature, 1),
+		sigEndCh: make(chan []presign.Signature, 1),
+	}
+}
+
+// presigning initiator function, makes req.Count presignatures of the key of
+// req.KeyID for this node. It blocks until they are stored, ctx is done or the
+// session times out.
+func (tsr *TssReactor) Presign(ctx context.Context, req PresignRequest) *PresignResult {
+	parties := req.Parties
+	if len(parties) != 0 {
+		if !containsPeer(parties, tsr.localAddr) {
+			return &PresignResult{KeyID: req.KeyID, Err: tss.NewError(errors.New("local node is not in the signing parties"), presign.TaskName, -1, nil)}
+		}
+		// the owner of the presignatures goes first
+		parties = []string{tsr.localAddr}
+		for _, id := range req.Parties {
+			if id != tsr.localAddr {
+				parties = append(parties, id)
+			}
+		}
+	}
+	resCh, err := tsr.presign(ctx, req.KeyID, newSessionID(), parties, req.Count)
+	if err != nil {
+		return &PresignResult{KeyID: req.KeyID, Err: tss.NewError(err, presign.TaskName, -1, nil)}
+	}
+	return <-resCh
+}
+
+// PresignatureCount returns the number of presignatures of keyID this node
+// has left for its signings.
+func (tsr *TssReactor) PresignatureCount(keyID KeyID) int {
+	n := 0
+	it := tsr.tssStore.Iterator(presigOwnerPrefix(keyID, tsr.localAddr))
+	for ; it.Valid(); it.Next() {
+		n++
+	}
+	it.Close()
+	return n
+}
+
+func (tsr *TssReactor) presign(ctx context.Context, keyID KeyID, sid SessionID, parties []string, count int) (chan *PresignResult, error) {
+	// the presignatures could not be stored at the end
+	if tsr.keyring.locked() {
+		return nil, ErrStoreLocked
+	}
+	if count < 1 || count > MaxBatchSize {
+		return nil, fmt.Errorf("a presigning makes 1 to %d presignatures, got %d", MaxBatchSize, count)
+	}
+	saveData, parties, params, err := tsr.ecdsaQuorum(keyID, parties)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tsr.sessions.timeout)
+	s := &session{keyID: keyID, pIDs: params.Parties().IDs(), inbox: newSessionInbox(), cancel: cancel}
+	if err := tsr.sessions.add(sid, s); err != nil {
+		cancel()
+		return nil, err
+	}
+	ch := newPresignChannels(len(parties))
+	localParty := presign.NewLocalParty(count, params, saveData.PartySaveData.LocalPartySaveData, ch.outCh, ch.endCh)
+
+	go tsr.inboxRoutine(ctx, s.inbox, []tss.Party{localParty}, func(inboxMsg) tss.Party { return localParty }, ch.errCh)
+
+	// buffered, parties that joined the session never read the result
+	resCh := make(chan *PresignResult, 1)
+	go tsr.presigningRoutine(ctx, localParty, keyID, sid, saveData.ConfigSaveData.Thresold, parties, count, ch, resCh)
+	return resCh, nil
+}
+
+// ecdsaQuorum checks that keyID is an ECDSA key this node may sign with and
+// returns its share, the signing quorum and the parameters of the party of
+// this node in it. No parties pick the quorum from the connected peers.
+func (tsr *TssReactor) ecdsaQuorum(keyID KeyID, parties []string) (SaveData, []string, *tss.Parameters, error) {
+	saveData, err := tsr.signableKey(keyID)
+	if err != nil {
+		return saveData, nil, nil, err
+	}
+	if saveData.PartySaveData.keyType() != KeyTypeECDSA {
+		return saveData, nil, nil, errors.New("only ECDSA keys have presignatures")
+	}
+	if len(parties) == 0 {
+		if parties, err = tsr.selectQuorum(saveData); err != nil {
+			return saveData, nil, nil, err
+		}
+	}
+	if err := tsr.validateQuorum(saveData, parties); err != nil {
+		return saveData, nil, nil, err
+	}
+	if err := tsr.checkTrusted(parties); err != nil {
+		return saveData, nil, nil, err
+	}
+	signPIDs := subsetPIDs(saveData.PartySaveData.SortedPartyIDs, parties)
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	partyIndex := findPartyIndex(signPIDs, tsr.localAddr)
+	params := tss.NewParameters(saveData.PartySaveData.share().ec, p2pCtx, signPIDs[partyIndex], len(signPIDs), saveData.ConfigSaveData.Thresold)
+	return saveData, parties, params, nil
+}
+
+// signableKey checks that keyID is a key this node may sign with and returns
+// its share.
+func (tsr *TssReactor) signableKey(keyID KeyID) (SaveData, error) {
+	if err := tsr.checkNotRetired(keyID); err != nil {
+		return SaveData{}, err
+	}
+	saveData, err := tsr.getSaveData(keyID)
+	if err != nil {
+		return saveData, err
+	}
+	if err := tsr.validateSaveData(saveData); err != nil {
+		return saveData, err
+	}
+	return saveData, nil
+}
+
+func (tsr *TssReactor) presigningRoutine(ctx context.Context, party tss.Party, keyID KeyID, sid SessionID, threshold int, parties []string, count int, ch presignChannels, resCh chan *PresignResult) {
+	defer tsr.sessions.end(sid)
+
+	fail := func(err *tss.Error) {
+		tsr.Logger.Error("presigning err", "key", keyID, "sid", sid, "err", err, "culprits", err.Culprits())
+		tsr.recordCulprits(err)
+		resCh <- &PresignResult{KeyID: keyID, Err: err}
+	}
+	tssmsg := TssMessage{KeyID: keyID, Sid: sid, Threshold: threshold, PmsgType: PresignMsg, Parties: parties, Count: count}
+	for {
+		select {
+		case <-ctx.Done():
+			// the parties we still wait for are the ones that hang
+			fail(party.WrapError(ctx.Err(), tsr.waitingForPeers(ctx, party)...))
+			return
+
+		case err := <-ch.errCh:
+			fail(err)
+			return
+
+		case pmsg := <-ch.outCh:
+			if err := tsr.sendPartyMsg(party, parties, pmsg, tssmsg); err != nil {
+				fail(err)
+				return
+			}
+
+		case presigs := <-ch.endCh:
+			if err := tsr.flushPartyMsgs(party, parties, ch.outCh, tssmsg); err != nil {
+				fail(err)
+				return
+			}
+			if err := tsr.putPresignatures(keyID, sid, parties, presigs); err != nil {
+				fail(party.WrapError(err))
+				return
+			}
+			tsr.Logger.Info(fmt.Sprintf("key: %s sid: %s:presigning done", keyID, sid), "count", len(presigs))
+			tsr.recordGoodParties(parties)
+			resCh <- &PresignResult{KeyID: keyID, Count: len(presigs)}
+			return
+		}
+	}
+}
+
+// batch signing initiator function, signs req.Digests with the key of
+// req.KeyID in one session. The results are in the order of the digests, a
+// digest that could not be signed has its own error. It blocks like Sign.
+func (tsr *TssReactor) SignBatch(ctx context.Context, req BatchSignRequest) []*SignResult {
+	// checked before presignatures are taken for them
+	if err := checkDigests(req.Digests); err != nil {
+		return batchFailed(req.KeyID, len(req.Digests), req.HashAlg, tss.NewError(err, presign.TaskName, -1, nil))
+	}
+	parties := req.Parties
+	var presigIDs []string
+	var presigs []presign.Presignature
+	// a child key signs with presignatures made for its digests, see
+	// errPresigWithPath
+	if len(req.Path) == 0 {
+		var presigParties []string
+		var err error
+		presigIDs, presigs, presigParties, err = tsr.takePresignatures(req.KeyID, req.Parties, len(req.Digests))
+		if err != nil {
+			tsr.Logger.Error("take presignatures failed", "key", req.KeyID, "err", err)
+		}
+		if presigIDs != nil {
+			parties = presigParties
+		}
+	}
+	return tsr.signBatch(ctx, req.KeyID, req.Digests, req.HashAlg, parties, req.Path, presigIDs, presigs)
+}
+
+func (tsr *TssReactor) signBatch(ctx context.Context, keyID KeyID, digests [][]byte, hashAlg HashAlg, parties []string, path []uint32, presigIDs []string, presigs []presign.Presignature) []*SignResult {
+	resCh, err := tsr.batchSigning(ctx, digests, keyID, newSessionID(), parties, path, presigIDs, presigs)
+	if err != nil {
+		return batchFailed(keyID, len(digests), hashAlg, tss.NewError(err, presign.TaskName, -1, nil))
+	}
+	results := <-resCh
+	for _, res := range results {
+		res.HashAlg = hashAlg
+	}
+	return results
+}
+
+func checkDigests(digests [][]byte) error {
+	if len(digests) == 0 || len(digests) > MaxBatchSize {
+		return fmt.Errorf("a batch signs 1 to %d digests, got %d", MaxBatchSize, len(digests))
+	}
+	for b, digest := range digests {
+		if len(digest) > 32 {
+			return fmt.Errorf("msg %d is not a 32 byte digest", b)
+		}
+	}
+	return nil
+}
+
+// batchFailed returns the results of a batch of n digests that failed with err.
+func batchFailed(keyID KeyID, n int, hashAlg HashAlg, err *tss.Error) []*SignResult {
+	results := make([]*SignResult, n)
+	for b := range results {
+		results[b] = &SignResult{KeyID: keyID, HashAlg: hashAlg, Err: err}
+	}
+	return results
+}
+
+// batchSigning signs digests with presigs, or with the presignatures of
+// presigIDs taken from the store once the session is added. Without either
+// the session makes the presignatures first.
+func (tsr *TssReactor) batchSigning(ctx context.Context, digests [][]byte, keyID KeyID, sid SessionID, parties []string, path []uint32, presigIDs []string, presigs []presign.Presignature) (chan []*SignResult, error) {
+	if err := checkDigests(digests); err != nil {
+		return nil, err
+	}
+	if len(presigIDs) != 0 && len(presigIDs) != len(digests) {
+		return nil, fmt.Errorf("%d presignatures for %d digests", len(presigIDs), len(digests))
+	}
+	if len(path) != 0 && (len(presigIDs) != 0 || presigs != nil) {
+		return nil, errPresigWithPath
+	}
+	// ECDSA signs digests, kept with their leading zero bytes
+	msgs := make([][]byte, len(digests))
+	ms := make([]*big.Int, len(digests))
+	for b, digest := range digests {
+		msgs[b] = make([]byte, 32)
+		copy(msgs[b][32-len(digest):], digest)
+		ms[b] = new(big.Int).SetBytes(digest)
+	}
+	saveData, parties, params, err := tsr.ecdsaQuorum(keyID, parties)
+	if err != nil {
+		return nil, err
+	}
+	// a child key signs with presignatures the session makes, the tweak of
+	// the child is added in the online round
+	key := saveData.PartySaveData.LocalPartySaveData
+	var tweak *big.Int
+	if len(path) != 0 {
+		if _, tweak, err = deriveChild(key.ECDSAPub, chainCodeOf(saveData), path); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tsr.sessions.timeout)
+	s := &session{keyID: keyID, pIDs: params.Parties().IDs(), inbox: newSessionInbox(), cancel: cancel}
+	if err := tsr.sessions.add(sid, s); err != nil {
+		cancel()
+		return nil, err
+	}
+	// taken once the session is added, a session joined twice must not
+	// take them twice
+	if presigs == nil && len(presigIDs) != 0 {
+		if presigs, err = tsr.takePresignaturesByID(keyID, presigIDs, parties); err != nil {
+			tsr.sessions.end(sid)
+			return nil, err
+		}
+	}
+	ch := newPresignChannels(len(parties))
+	localParty := presign.NewSigningParty(ms, tweak, params, key, presigs, ch.outCh, ch.sigEndCh)
+
+	go tsr.inboxRoutine(ctx, s.inbox, []tss.Party{localParty}, func(inboxMsg) tss.Party { return localParty }, ch.errCh)
+
+	// buffered, parties that joined the session never read the result
+	resCh := make(chan []*SignResult, 1)
+	tssmsg := TssMessage{
+		KeyID:     keyID,
+		Sid:       sid,
+		Threshold: saveData.ConfigSaveData.Thresold,
+		PmsgType:  SigningMsg,
+		Parties:   parties,
+		Path:      path,
+		Msgs:      msgs,
+		Presigs:   presigIDs,
+	}
+	go tsr.batchSigningRoutine(ctx, localParty, tssmsg, ch, resCh)
+	return resCh, nil
+}
+
+func (tsr *TssReactor) batchSigningRoutine(ctx context.Context, party tss.Party, tssmsg TssMessage, ch presignChannels, resCh chan []*SignResult) {
+	keyID, sid := tssmsg.KeyID, tssmsg.Sid
+	defer tsr.sessions.end(sid)
+
+	fail := func(err *tss.Error) {
+		tsr.Logger.Error("batch signing err", "key", keyID, "sid", sid, "err", err, "culprits", err.Culprits())
+		tsr.recordCulprits(err)
+		resCh <- batchFailed(keyID, len(tssmsg.Msgs), "", err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			// the parties we still wait for are the ones that hang
+			fail(party.WrapError(ctx.Err(), tsr.waitingForPeers(ctx, party)...))
+			return
+
+		case err := <-ch.errCh:
+			fail(err)
+			return
+
+		case pmsg := <-ch.outCh:
+			if err := tsr.sendPartyMsg(party, tssmsg.Parties, pmsg, tssmsg); err != nil {
+				fail(err)
+				return
+			}
+
+		case sigs := <-ch.sigEndCh:
+			if err := tsr.flushPartyMsgs(party, tssmsg.Parties, ch.outCh, tssmsg); err != nil {
+				fail(err)
+				return
+			}
+			results := make([]*SignResult, len(sigs))
+			for b, sig := range sigs {
+				results[b] = &SignResult{KeyID: keyID}
+				if sig.Err != nil {
+					results[b].Err = party.WrapError(fmt.Errorf("msg %d: %v", b, sig.Err))
+					continue
+				}
+				// M keeps the leading zero bytes of the digest
+				sig.Data.M = tssmsg.Msgs[b]
+				results[b].Signature = sig.Data
+			}
+			tsr.Logger.Info(fmt.Sprintf("key: %s sid: %s:batch signing done", keyID, sid), "count", len(sigs))
+			tsr.touchKey(keyID)
+			tsr.recordGoodParties(tssmsg.Parties)
+			resCh <- results
+			return
+		}
+	}
+}
+
+// sendPartyMsg sends pmsg of party to the parties it is for, wrapped in a
+// copy of tssmsg.
+func (tsr *TssReactor) sendPartyMsg(party tss.Party, parties []string, pmsg tss.Message, tssmsg TssMessage) *tss.Error {
+	bz, _, err := pmsg.WireBytes()
+	if err != nil {
+		return party.WrapError(err)
+	}
+	tssmsg.From = pmsg.GetFrom()
+	tssmsg.Isbroadcast = pmsg.IsBroadcast()
+	tssmsg.Pmsg = bz
+
+	dest := pmsg.GetTo()
+	if dest == nil {
+		for _, id := range parties {
+			if id == pmsg.GetFrom().Id {
+				continue
+			}
+			msg := tssmsg
+			if err := tsr.trySend(id, &msg); err != nil {
+				return party.WrapError(err)
+			}
+		}
+		return nil
+	}
+	if dest[0].Id == pmsg.GetFrom().Id {
+		return party.WrapError(fmt.Errorf("party %d tried to send a message to itself (%d)", dest[0].Index, pmsg.GetFrom().Index))
+	}
+	if err := tsr.trySend(dest[0].Id, &tssmsg); err != nil {
+		return party.WrapError(err, dest...)
+	}
+	return nil
+}
+
+// flushPartyMsgs sends the msgs party left in outCh. A party may end in the
+// round it sends its last msg in, with presignatures it has a single round, so
+// its msgs are sent before the session ends.
+func (tsr *TssReactor) flushPartyMsgs(party tss.Party, parties []string, outCh chan tss.Message, tssmsg TssMessage) *tss.Error {
+	for {
+		select {
+		case pmsg := <-outCh:
+			if err := tsr.sendPartyMsg(party, parties, pmsg, tssmsg); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+// putPresignatures seals the presignatures of session sid and stores them
+// under the owner, the first of parties.
+func (tsr *TssReactor) putPresignatures(keyID KeyID, sid SessionID, parties []string, presigs []presign.Presignature) error {
+	for i, presig := range presigs {
+		bz, err := json.Marshal(storedPresignature{Presignature: presig, Parties: parties})
+		if err != nil {
+			return err
+		}
+		key := presigEntryKey(keyID, fmt.Sprintf("%s/%s/%06d", parties[0], sid, i))
+		sealed, err := tsr.keyring.seal(key, bz)
+		if err != nil {
+			return err
+		}
+		tsr.tssStore.Set(key, sealed)
+	}
+	return nil
+}
+
+// takePresignatures removes n presignatures of keyID this node owns from the
+// store, all made for the same parties, for parties if they are set, and
+// returns their ids with them. It returns none if there are not enough, or
+// the parties of the presignatures are not connected. The key and the parties
+// are checked before any presignature is taken, a signing that is refused
+// would lose them.
+func (tsr *TssReactor) takePresignatures(keyID KeyID, parties []string, n int) ([]string, []presign.Presignature, []string, error) {
+	tsr.presigMtx.Lock()
+	defer tsr.presigMtx.Unlock()
+	if tsr.keyring.locked() {
+		return nil, nil, nil, nil
+	}
+	saveData, err := tsr.signableKey(keyID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if saveData.PartySaveData.keyType() != KeyTypeECDSA {
+		return nil, nil, nil, nil
+	}
+	if len(parties) != 0 {
+		if err := tsr.validateQuorum(saveData, parties); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := tsr.checkTrusted(parties); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	prefix := presigKeyPrefix(keyID)
+	groups := make(map[string][]string)
+	var order []string
+	it := tsr.tssStore.Iterator(presigOwnerPrefix(keyID, tsr.localAddr))
+	for ; it.Valid(); it.Next() {
+		key := append([]byte{}, it.Key()...)
+		stored, err := tsr.openPresignature(key, it.Value())
+		if err != nil {
+			it.Close()
+			return nil, nil, nil, err
+		}
+		quorum := strings.Join(stored.Parties, ",")
+		if _, ok := groups[quorum]; !ok {
+			order = append(order, quorum)
+		}
+		groups[quorum] = append(groups[quorum], string(key[len(prefix):]))
+	}
+	it.Close()
+
+	for _, quorum := range order {
+		ids := groups[quorum]
+		presigParties := strings.Split(quorum, ",")
+		if len(ids) < n || !tsr.samePeers(parties, presigParties) {
+			continue
+		}
+		if tsr.validateQuorum(saveData, presigParties) != nil || tsr.checkTrusted(presigParties) != nil {
+			continue
+		}
+		presigs, err := tsr.takePresignaturesByID(keyID, ids[:n], presigParties)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return ids[:n], presigs, presigParties, nil
+	}
+	return nil, nil, nil, nil
+}
+
+// samePeers reports whether presignatures of presigParties may sign for
+// parties, or for any quorum if parties is empty, as long as every party is
+// connected.
+func (tsr *TssReactor) samePeers(parties, presigParties []string) bool {
+	if len(parties) != 0 {
+		if len(parties) != len(presigParties) {
+			return false
+		}
+		for _, id := range parties {
+			if !containsPeer(presigParties, id) {
+				return false
+			}
+		}
+	}
+	for _, id := range presigParties {
+		if id != tsr.localAddr && !tsr.Switch.Peers().Has(p2p.ID(id)) {
+			return false
+		}
+	}
+	return true
+}
+
+// takePresignaturesByID removes the presignatures of ids from the store, each
+// is deleted before it is used, so it signs once only, also across restarts.
+// They have to be made for parties and owned by the first of them.
+func (tsr *TssReactor) takePresignaturesByID(keyID KeyID, ids []string, parties []string) ([]presign.Presignature, error) {
+	presigs := make([]presign.Presignature, len(ids))
+	for b, id := range ids {
+		if !strings.HasPrefix(id, parties[0]+"/") {
+			return nil, fmt.Errorf("presignature %s is not owned by %s", id, parties[0])
+		}
+		key := presigEntryKey(keyID, id)
+		bz := tsr.tssStore.Get(key)
+		if bz == nil {
+			return nil, fmt.Errorf("no presignature %s", id)
+		}
+		tsr.tssStore.Delete(key)
+		stored, err := tsr.openPresignature(key, bz)
+		if err != nil {
+			return nil, fmt.Errorf("presignature %s: %v", id, err)
+		}
+		if len(stored.Parties) != len(parties) {
+			return nil, fmt.Errorf("presignature %s is of other parties", id)
+		}
+		for _, party := range parties {
+			if !containsPeer(stored.Parties, party) {
+				return nil, fmt.Errorf("presignature %s is of other parties", id)
+			}
+		}
+		presigs[b] = stored.Presignature
+	}
+	tsr.wakePresignPool()
+	return presigs, nil
+}
+
+func (tsr *TssReactor) openPresignature(key, bz []byte) (*storedPresignature, error) {
+	plain, err := tsr.keyring.open(key, bz)
+	if err != nil {
+		return nil, err
+	}
+	stored := new(storedPresignature)
+	if err := json.Unmarshal(plain, stored); err != nil {
+		return nil, err
+	}
+	if len(stored.Parties) == 0 {
+		return nil, errors.New("presignature has no parties")
+	}
+	return stored, nil
+}
+
+// dropPresignatures deletes the presignatures of keyID, they are of a share
+// the node does not hold anymore.
+func (tsr *TssReactor) dropPresignatures(keyID KeyID) {
+	tsr.presigMtx.Lock()
+	defer tsr.presigMtx.Unlock()
+	var keys [][]byte
+	it := tsr.tssStore.Iterator(presigKeyPrefix(keyID))
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, append([]byte{}, it.Key()...))
+	}
+	it.Close()
+	for _, key := range keys {
+		tsr.tssStore.Delete(key)
+	}
+}
+
+// wakePresignPool wakes the pool routine after presignatures were taken.
+func (tsr *TssReactor) wakePresignPool() {
+	select {
+	case tsr.presignNotify <- struct{}{}:
+	default:
+	}
+}
+
+// presignPoolRoutine keeps PresignaturePoolSize presignatures of every ECDSA
+// key of this node until the reactor stops. Presignatures are only made while
+// no other session runs.
+func (tsr *TssReactor) presignPoolRoutine() {
+	ticker := time.NewTicker(presignPoolInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-tsr.presignNotify:
+		case <-tsr.Quit():
+			return
+		}
+		tsr.fillPresignPools()
+	}
+}
+
+func (tsr *TssReactor) fillPresignPools() {
+	if tsr.keyring.locked() {
+		return
+	}
+	infos, err := tsr.ListKeys()
+	if err != nil {
+		tsr.Logger.Error("list keys failed", "err", err)
+		return
+	}
+	for _, info := range infos {
+		if info.KeyType.normalized() != KeyTypeECDSA || info.Retired || !containsPeer(info.Committee, tsr.localAddr) {
+			continue
+		}
+		missing := tsr.tssConfig.PresignaturePoolSize - tsr.PresignatureCount(info.KeyID)
+		if missing <= 0 {
+			continue
+		}
+		if missing > MaxBatchSize {
+			missing = MaxBatchSize
+		}
+		if tsr.sessions.size() > 0 || !tsr.IsRunning() {
+			return
+		}
+		res := tsr.Presign(context.Background(), PresignRequest{KeyID: info.KeyID, Count: missing})
+		if res.Err != nil {
+			tsr.Logger.Error("presigning failed", "key", info.KeyID, "err", res.Err)
+			continue
+		}
+		tsr.Logger.Info("presignatures made", "key", info.KeyID, "count", res.Count)
+	}
+}
+
+// the key id is hex encoded, so the presignatures of one key id are never
+// under the prefix of another
+func presigKeyPrefix(keyID KeyID) []byte {
+	return []byte(fmt.Sprintf("%s/%x/", presigKey, keyID))
+}
+
+func presigOwnerPrefix(keyID KeyID, owner string) []byte {
+	return append(presigKeyPrefix(keyID), owner+"/"...)
+}
+
+// presigEntryKey returns the store key of the presignature id of keyID, ids
+// are owner/sid/index.
+func presigEntryKey(keyID KeyID, id string) []byte {
+	return append(presigKeyPrefix(keyID), id...)
+}
@@ -0,0 +1,344 @@
+package threshold
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	cfg "CipherMachine/config"
+	"CipherMachine/p2p"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/ecdsa/presign"
+	"CipherMachine/tsslib/tss"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// a batch signs its digests in one session, each digest with its own nonce
+func TestSignBatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	reactors := makeTestReactors(t, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	res := reactors[0].Keygen(ctx, KeygenRequest{KeyID: "key", Threshold: 1})
+	require.Nil(t, res.Err)
+	waitForShares(t, reactors, "key")
+
+	digests := [][]byte{{1}, {2}, {3}}
+	results := reactors[0].SignBatch(ctx, BatchSignRequest{KeyID: "key", Digests: digests, HashAlg: HashSHA256})
+	require.Len(t, results, len(digests))
+	for b, r := range results {
+		require.Nil(t, r.Err)
+		require.Equal(t, HashSHA256, r.HashAlg)
+		requireSigned(t, res.PubKey, digests[b], r)
+	}
+	waitForSessions(t, reactors)
+
+	// the child key at path signs the batch
+	path := []uint32{0, 7}
+	child, err := reactors[0].DerivePubKey("key", path)
+	require.NoError(t, err)
+	results = reactors[0].SignBatch(ctx, BatchSignRequest{KeyID: "key", Digests: [][]byte{{4}}, Path: path})
+	require.Len(t, results, 1)
+	require.Nil(t, results[0].Err)
+	requireSigned(t, child, []byte{4}, results[0])
+	waitForSessions(t, reactors)
+
+	// a batch that is too large fails every digest
+	results = reactors[0].SignBatch(ctx, BatchSignRequest{KeyID: "key", Digests: make([][]byte, MaxBatchSize+1)})
+	require.Len(t, results, MaxBatchSize+1)
+	for _, r := range results {
+		require.NotNil(t, r.Err)
+	}
+}
+
+// presignatures are made ahead, used once by the signings of their owner, and
+// a batch without enough of them makes them in its own session
+func TestPresignAndBatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	reactors := makeTestReactors(t, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	res := reactors[0].Keygen(ctx, KeygenRequest{KeyID: "key", Threshold: 1})
+	require.Nil(t, res.Err)
+	waitForShares(t, reactors, "key")
+
+	pres := reactors[0].Presign(ctx, PresignRequest{KeyID: "key", Count: 2})
+	require.Nil(t, pres.Err)
+	require.Equal(t, 2, pres.Count)
+	require.Equal(t, 2, reactors[0].PresignatureCount("key"))
+	require.Equal(t, 0, reactors[1].PresignatureCount("key"))
+	waitForSessions(t, reactors)
+	require.Equal(t, 4, storedPresignatures(reactors, "key"))
+
+	sres := reactors[0].Sign(ctx, SignRequest{KeyID: "key", Msg: big.NewInt(42)})
+	require.Nil(t, sres.Err)
+	requireSigned(t, res.PubKey, big.NewInt(42).Bytes(), sres)
+	require.Equal(t, 1, reactors[0].PresignatureCount("key"))
+	// the other party deleted its half as well
+	waitForSessions(t, reactors)
+	require.Equal(t, 2, storedPresignatures(reactors, "key"))
+
+	// not enough presignatures for the batch
+	digests := [][]byte{{1}, {2}, {3}}
+	results := reactors[0].SignBatch(ctx, BatchSignRequest{KeyID: "key", Digests: digests, HashAlg: HashSHA256})
+	require.Len(t, results, len(digests))
+	for b, r := range results {
+		require.Nil(t, r.Err)
+		require.Equal(t, HashSHA256, r.HashAlg)
+		requireSigned(t, res.PubKey, digests[b], r)
+	}
+	require.Equal(t, 1, reactors[0].PresignatureCount("key"))
+
+	// a child key never signs with a presignature, its session makes one
+	path := []uint32{0, 7}
+	child, err := reactors[0].DerivePubKey("key", path)
+	require.NoError(t, err)
+	results = reactors[0].SignBatch(ctx, BatchSignRequest{KeyID: "key", Digests: [][]byte{{4}}, Path: path})
+	require.Len(t, results, 1)
+	require.Nil(t, results[0].Err)
+	requireSigned(t, child, []byte{4}, results[0])
+	require.Equal(t, 1, reactors[0].PresignatureCount("key"))
+	waitForSessions(t, reactors)
+	require.Equal(t, 2, storedPresignatures(reactors, "key"))
+	_, err = reactors[0].batchSigning(ctx, [][]byte{{5}}, "key", newSessionID(), nil, path, []string{"id"}, nil)
+	require.Equal(t, errPresigWithPath, err)
+
+	// the key signs with the last presignature
+	sres = reactors[0].Sign(ctx, SignRequest{KeyID: "key", Msg: big.NewInt(43)})
+	require.Nil(t, sres.Err)
+	requireSigned(t, res.PubKey, big.NewInt(43).Bytes(), sres)
+	require.Equal(t, 0, reactors[0].PresignatureCount("key"))
+	waitForSessions(t, reactors)
+	require.Equal(t, 0, storedPresignatures(reactors, "key"))
+
+	// a refused signing keeps the presignatures
+	pres = reactors[0].Presign(ctx, PresignRequest{KeyID: "key", Count: 1})
+	require.Nil(t, pres.Err)
+	waitForSessions(t, reactors)
+	require.NoError(t, reactors[0].RetireKey("key"))
+	sres = reactors[0].Sign(ctx, SignRequest{KeyID: "key", Msg: big.NewInt(44)})
+	require.NotNil(t, sres.Err)
+	require.Equal(t, 1, reactors[0].PresignatureCount("key"))
+}
+
+// signings that look for presignatures and the deletion of their key take
+// the key and presignature locks in the same order
+func TestSignWhileDeletingKey(t *testing.T) {
+	config := cfg.TestConfig()
+	db := dbm.NewMemDB()
+	require.NoError(t, InitStore(db, StoreSecret{Passphrase: "test"}))
+	tsr := NewTssReactor(config, db, "aa")
+	tsr.SetLogger(log.TestingLogger())
+	p2p.MakeSwitch(config.P2P, 0, p2p.TEST_HOST, "123.123.123", func(i int, sw *p2p.Switch) *p2p.Switch {
+		sw.AddReactor("tss", tsr)
+		return sw
+	})
+	tsr.localAddr = "aa"
+	tsr.peers = []string{"aa", "bb"}
+	require.NoError(t, tsr.Unlock(StoreSecret{Passphrase: "test"}))
+
+	saveData := loadTestSaveData(t, 0)
+	confirm := hex.EncodeToString(saveData.PartySaveData.LocalPartySaveData.ECDSAPub.SerializeCompressed())
+	for i := 0; i < 50; i++ {
+		require.NoError(t, tsr.setSaveData("key", saveData))
+		tsr.updateKeyInfo("key", saveData)
+		require.NoError(t, tsr.putPresignatures("key", newSessionID(), []string{"aa", "bb"}, make([]presign.Presignature, 2)))
+		require.NoError(t, tsr.RetireKey("key"))
+
+		var wg sync.WaitGroup
+		deleted := make(chan struct{})
+		for j := 0; j < 8; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					tsr.Sign(context.Background(), SignRequest{KeyID: "key", Msg: big.NewInt(42)})
+					select {
+					case <-deleted:
+						return
+					default:
+					}
+				}
+			}()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(deleted)
+			if err := tsr.DeleteKey("key", confirm); err != nil {
+				t.Error(err)
+			}
+		}()
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatal("signing and deleting the key deadlocked")
+		}
+		require.Equal(t, 0, storedPresignatures([]*TssReactor{tsr}, "key"))
+	}
+}
+
+// presignatures are only taken on a msg of their owner, the msg of another
+// party naming them waits for it
+func TestPresigNonOwnerMsg(t *testing.T) {
+	tsr, saveData := newPresigTestReactor(t)
+	presigSid := newSessionID()
+	require.NoError(t, tsr.putPresignatures("key", presigSid, []string{"bb", "aa"}, make([]presign.Presignature, 1)))
+	msgFrom := func(from *tss.PartyID) []byte {
+		pmsg, _, err := presign.NewPresignRound5Message(from, []*big.Int{big.NewInt(1)}).WireBytes()
+		require.NoError(t, err)
+		bz, err := cdc.MarshalBinaryBare(TssMessage{
+			KeyID:       "key",
+			Sid:         "sid",
+			Threshold:   1,
+			PmsgType:    SigningMsg,
+			Parties:     []string{"bb", "aa"},
+			Msgs:        [][]byte{make([]byte, 32)},
+			Presigs:     []string{fmt.Sprintf("bb/%s/%06d", presigSid, 0)},
+			From:        from,
+			Isbroadcast: true,
+			Pmsg:        pmsg,
+		})
+		require.NoError(t, err)
+		return bz
+	}
+
+	tsr.Receive(TssChannel, testPeer{id: "cc"}, msgFrom(tss.NewPartyID("cc", "", big.NewInt(3))))
+	require.Equal(t, 0, tsr.sessions.size())
+	require.Equal(t, 1, storedPresignatures([]*TssReactor{tsr}, "key"))
+
+	pIDs := saveData.PartySaveData.SortedPartyIDs
+	tsr.Receive(TssChannel, testPeer{id: "bb"}, msgFrom(pIDs[findPartyIndex(pIDs, "bb")]))
+	require.Equal(t, 0, storedPresignatures([]*TssReactor{tsr}, "key"))
+	waitForSessions(t, []*TssReactor{tsr})
+}
+
+// a child key never signs with a presignature, the key itself does
+func TestPresigChildPath(t *testing.T) {
+	tsr, _ := newPresigTestReactor(t)
+	require.NoError(t, tsr.putPresignatures("key", newSessionID(), []string{"aa", "bb"}, make([]presign.Presignature, 2)))
+	path := []uint32{0, 7}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NotNil(t, tsr.Sign(ctx, SignRequest{KeyID: "key", Msg: big.NewInt(42), Path: path}).Err)
+	require.NotNil(t, tsr.SignBatch(ctx, BatchSignRequest{KeyID: "key", Digests: [][]byte{{1}}, Path: path})[0].Err)
+	require.Equal(t, 2, tsr.PresignatureCount("key"))
+	_, err := tsr.batchSigning(ctx, [][]byte{{1}}, "key", newSessionID(), nil, path, []string{"id"}, nil)
+	require.Equal(t, errPresigWithPath, err)
+
+	// bb never answers, the presignature is used up anyway
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NotNil(t, tsr.Sign(ctx, SignRequest{KeyID: "key", Msg: big.NewInt(42)}).Err)
+	require.Equal(t, 1, tsr.PresignatureCount("key"))
+}
+
+// the key and the quorum are checked before presignatures are taken, a
+// refused signing would lose them
+func TestPresigCheckedBeforeTaken(t *testing.T) {
+	tsr, _ := newPresigTestReactor(t)
+	require.NoError(t, tsr.putPresignatures("key", newSessionID(), []string{"aa", "bb"}, make([]presign.Presignature, 2)))
+	_, _, _, err := tsr.takePresignatures("key", []string{"aa", "dd"}, 1)
+	require.Error(t, err)
+	require.Equal(t, 2, tsr.PresignatureCount("key"))
+
+	require.NoError(t, tsr.RetireKey("key"))
+	require.NotNil(t, tsr.Sign(context.Background(), SignRequest{KeyID: "key", Msg: big.NewInt(42)}).Err)
+	require.NotNil(t, tsr.SignBatch(context.Background(), BatchSignRequest{KeyID: "key", Digests: [][]byte{{1}, {2}}})[0].Err)
+	require.Equal(t, 2, tsr.PresignatureCount("key"))
+}
+
+// msgs of a presignature signing that come before its owner's are held until
+// the owner's msg adds the session
+func TestHeldMsgs(t *testing.T) {
+	r := newSessionRegistry(time.Minute)
+	pIDs := generatePIDs([]string{"aa", "bb", "cc"})
+	msgOf := func(from *tss.PartyID) inboxMsg {
+		return inboxMsg{pMsg: tss.NewMessage(tss.MessageRouting{From: from}, nil, nil)}
+	}
+	require.True(t, r.hold("sid", msgOf(pIDs[1])))
+	// a msg of a party not in the session is dropped when it is added
+	require.True(t, r.hold("sid", msgOf(tss.NewPartyID("dd", "", big.NewInt(4)))))
+
+	s := &session{pIDs: pIDs, inbox: newSessionInbox(), cancel: func() {}}
+	require.NoError(t, r.add("sid", s))
+	require.Len(t, s.inbox.msgs, 1)
+	require.Equal(t, pIDs[1].Id, s.inbox.msgs[0].pMsg.GetFrom().Id)
+
+	// msgs of an added or ended session are not held
+	require.False(t, r.hold("sid", msgOf(pIDs[2])))
+	r.end("sid")
+	require.False(t, r.hold("sid", msgOf(pIDs[2])))
+}
+
+// newPresigTestReactor returns the reactor of node aa with its share of the 2
+// party test key, bb is connected and drops the msgs sent to it.
+func newPresigTestReactor(t *testing.T) (*TssReactor, SaveData) {
+	config := cfg.TestConfig()
+	db := dbm.NewMemDB()
+	require.NoError(t, InitStore(db, StoreSecret{Passphrase: "test"}))
+	tsr := NewTssReactor(config, db, "aa")
+	tsr.SetLogger(log.TestingLogger())
+	p2p.MakeSwitch(config.P2P, 0, p2p.TEST_HOST, "123.123.123", func(i int, sw *p2p.Switch) *p2p.Switch {
+		sw.AddReactor("tss", tsr)
+		return sw
+	})
+	tsr.localAddr = "aa"
+	tsr.peers = []string{"aa", "bb"}
+	require.NoError(t, tsr.Switch.Peers().(*p2p.PeerSet).Add(sinkPeer{testPeer{id: "bb"}}))
+	require.NoError(t, tsr.Unlock(StoreSecret{Passphrase: "test"}))
+	saveData := loadTestSaveData(t, 0)
+	require.NoError(t, tsr.setSaveData("key", saveData))
+	tsr.updateKeyInfo("key", saveData)
+	return tsr, saveData
+}
+
+// sinkPeer is a connected peer that drops every msg.
+type sinkPeer struct {
+	testPeer
+}
+
+func (p sinkPeer) TrySend(chID byte, msgBytes []byte) bool { return true }
+
+func requireSigned(t *testing.T, pub *crypto.ECPoint, digest []byte, res *SignResult) {
+	pk := ecdsa.PublicKey{Curve: tss.EC(), X: pub.X(), Y: pub.Y()}
+	r, s := new(big.Int).SetBytes(res.Signature.R), new(big.Int).SetBytes(res.Signature.S)
+	require.True(t, ecdsa.Verify(&pk, digest, r, s))
+	require.Len(t, res.Signature.M, 32)
+}
+
+func waitForSessions(t *testing.T, reactors []*TssReactor) {
+	for _, r := range reactors {
+		require.Eventually(t, func() bool { return r.sessions.size() == 0 }, 30*time.Second, 100*time.Millisecond)
+	}
+}
+
+// storedPresignatures counts the presignatures of keyID on all nodes
+func storedPresignatures(reactors []*TssReactor, keyID KeyID) int {
+	n := 0
+	for _, r := range reactors {
+		it := r.tssStore.Iterator(presigKeyPrefix(keyID))
+		for ; it.Valid(); it.Next() {
+			n++
+		}
+		it.Close()
+	}
+	return n
+}
@@ -1,39 +1,49 @@
 package threshold
 
 import (
-	"CipherMachine/config"
+	"context"
+	cfg "CipherMachine/config"
 	"CipherMachine/p2p"
 	"CipherMachine/p2p/conn"
+	"CipherMachine/p2p/trust"
 	"CipherMachine/store"
 	"CipherMachine/tsslib/common"
 	"CipherMachine/tsslib/ecdsa/keygen"
+	eddsakeygen "CipherMachine/tsslib/eddsa/keygen"
+	eddsaresharing "CipherMachine/tsslib/eddsa/resharing"
+	eddsasigning "CipherMachine/tsslib/eddsa/signing"
+	frostkeygen "CipherMachine/tsslib/frost/keygen"
+	frostsigning "CipherMachine/tsslib/frost/signing"
+	"CipherMachine/tsslib/ecdsa/resharing"
 	"crypto/ecdsa"
-	"os"
-	"path/filepath"
-	"runtime"
+	"crypto/sha256"
+	"sync"
 	"time"
 
-	//"CipherMachine/tsslib/ecdsa/resharing"
 	"CipherMachine/tsslib/ecdsa/signing"
 	"CipherMachine/tsslib/tss"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/tendermint/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
 	"math/big"
-	"reflect"
-	"sync"
 )
 
 const (
 	storeKey   = "tss"
+	trustKey   = "trust"
 	TssChannel = byte(0x50)
 	SaveDataKey = "SaveData"
 	KeygenMsg = "keygenMsg"
 	SigningMsg = "signingMsg"
+	ResharingMsg = "resharingMsg"
 )
 
-// secret id
+// key id, names a stored share of a secret
+type KeyID string
+
+// request id, unique for every keygen/signing/resharing run
 type SessionID string
 
 // TssReactor handles tss sign and verify by broadcasting amongst peers.
@@ -41,30 +51,62 @@ type TssReactor struct {
 	p2p.BaseReactor
 	tssStore store.Store
 
-	mtx    sync.Mutex
-
 	//localConfig
 	localAddr string
 	peers []string
 
-	keygenCh map[SessionID]struct {
-		errCh chan *tss.Error
-		outCh chan tss.Message
-		endCh chan keygen.LocalPartySaveData
-	}
+	//running keygen/signing/resharing sessions
+	sessions *sessionRegistry
+	//seals the shares and pre-params in tssStore
+	keyring *keyring
+	//pre-params generated in the background
+	preParamsPool *preParamsPool
+	//source of the keygen pre-params, the pool by default. No pre-params let
+	//the keygen party generate them
+	preParams func() (*keygen.LocalPreParams, error)
 
-	signingCh map[SessionID]struct {
-		errCh chan *tss.Error
-		outCh chan tss.Message
-		endCh chan common.SignatureData
-	}
+	tssConfig *cfg.TssConfig
+	//trust of the peers, lowered by the culprits of failed sessions
+	trustStore *trust.TrustMetricStore
+	//guards the key infos in tssStore
+	keysMtx sync.Mutex
+	//guards the presignatures in tssStore, wakes the presignature pool
+	//routine after presignatures were taken. It is taken before keysMtx
+	presigMtx     sync.Mutex
+	presignNotify chan struct{}
+}
+
+type keygenChannels struct {
+	errCh chan *tss.Error
+	outCh chan tss.Message
+	endCh chan keygen.LocalPartySaveData
+	// the EdDSA keygen ends EdDSA and Schnorr keygens
+	eddsaEndCh chan eddsakeygen.LocalPartySaveData
+}
+
+type signingChannels struct {
+	errCh chan *tss.Error
+	outCh chan tss.Message
+	endCh chan common.SignatureData
+}
 
-	//control keygen/signing routine
-	keygenRSwitch map[SessionID]bool
-	signingRSwitch map[SessionID]bool
+// the channels are buffered, so a party never blocks on a session routine
+// that has already ended. n is the number of parties of the session.
+func newKeygenChannels(n int) keygenChannels {
+	return keygenChannels{
+		errCh: make(chan *tss.Error, 1),
+		outCh: make(chan tss.Message, 2*n),
+		endCh: make(chan keygen.LocalPartySaveData, 2),
+		eddsaEndCh: make(chan eddsakeygen.LocalPartySaveData, 2),
+	}
+}
 
-	localParty map[SessionID]tss.Party
-	saveDatas map[SessionID]SaveData
+func newSigningChannels(n int) signingChannels {
+	return signingChannels{
+		errCh: make(chan *tss.Error, 1),
+		outCh: make(chan tss.Message, 2*n),
+		endCh: make(chan common.SignatureData, 1),
+	}
 }
 
 type SaveData struct {
@@ -86,8 +128,17 @@ type SaveData struct {
 //}
 
 type PartySaveData struct {
+	//empty for the ECDSA keys generated before EdDSA
+	KeyType KeyType
+	//share of an ECDSA key
 	LocalPartySaveData keygen.LocalPartySaveData
+	//share of an EdDSA key
+	EdDSASaveData *eddsakeygen.LocalPartySaveData `json:",omitempty"`
+	//share of a Schnorr key
+	SchnorrSaveData *frostkeygen.LocalPartySaveData `json:",omitempty"`
 	SortedPartyIDs tss.SortedPartyIDs
+	//BIP32 chain code of the key, agreed at keygen, ECDSA keys only
+	ChainCode []byte
 }
 
 type ConfigSaveData struct {
@@ -97,32 +148,71 @@ type ConfigSaveData struct {
 }
 
 // NewTssReactor returns a new TssReactor with the given config.
-func NewTssReactor(config *config.Config, storeDB dbm.DB, addr string) *TssReactor {
+func NewTssReactor(config *cfg.Config, storeDB dbm.DB, addr string) *TssReactor {
 	peers := splitPeerFromPersistentPeer(config.P2P.PersistentPeers)
 	tssStore := store.NewStore(storeDB, []byte(storeKey))
+	// config files written before the [tss] section existed
+	tssConfig := config.Tss
+	if tssConfig == nil {
+		tssConfig = cfg.DefaultTssConfig()
+	}
+	kr := newKeyring(tssStore)
 	tsR := &TssReactor{
 		localAddr: addr,
 		tssStore: tssStore,
 		peers: peers,
-		keygenCh: make(map[SessionID]struct {
-			errCh chan *tss.Error
-			outCh chan tss.Message
-			endCh chan keygen.LocalPartySaveData
-		}),
-		signingCh: make(map[SessionID]struct {
-			errCh chan *tss.Error
-			outCh chan tss.Message
-			endCh chan common.SignatureData
-		}),
-		localParty: make(map[SessionID]tss.Party),
-		saveDatas: make(map[SessionID]SaveData),
-		keygenRSwitch: make(map[SessionID]bool),
-		signingRSwitch: make(map[SessionID]bool),
+		sessions: newSessionRegistry(tssConfig.SessionTimeout),
+		tssConfig: tssConfig,
+		trustStore: trust.NewTrustMetricStore(dbm.NewPrefixDB(storeDB, []byte(trustKey)), trust.DefaultConfig()),
+		keyring: kr,
+		preParamsPool: newPreParamsPool(tssStore, kr, tssConfig.PreParamsPoolSize),
+		presignNotify: make(chan struct{}, 1),
 	}
+	tsR.preParams = tsR.preParamsPool.take
 	tsR.BaseReactor = *p2p.NewBaseReactor("TssReactor", tsR)
 	return tsR
 }
 
+func (tsr *TssReactor) SetLogger(l log.Logger) {
+	tsr.BaseReactor.SetLogger(l)
+	tsr.trustStore.SetLogger(l.With("module", "trust"))
+}
+
+func (tsr *TssReactor) OnStart() error {
+	if tsr.preParamsPool.size > 0 {
+		go tsr.preParamsRoutine()
+	}
+	if tsr.tssConfig.PresignaturePoolSize > 0 {
+		go tsr.presignPoolRoutine()
+	}
+	return tsr.trustStore.Start()
+}
+
+func (tsr *TssReactor) OnStop() {
+	tsr.trustStore.Stop()
+}
+
+// Unlock unlocks the shares in the tss store with secret, the store must
+// have been given its secret by InitStore.
+func (tsr *TssReactor) Unlock(secret StoreSecret) error {
+	if err := tsr.keyring.unlock(secret); err != nil {
+		return err
+	}
+	tsr.preParamsPool.wake()
+	tsr.wakePresignPool()
+	return nil
+}
+
+// Rekey replaces the secret of the tss store, the shares are not rewritten.
+func (tsr *TssReactor) Rekey(oldSecret, newSecret StoreSecret) error {
+	return tsr.keyring.rekey(oldSecret, newSecret)
+}
+
+// Locked reports whether the tss store still has to be unlocked.
+func (tsr *TssReactor) Locked() bool {
+	return tsr.keyring.locked()
+}
+
 func (tsr *TssReactor) SetSwitch(sw *p2p.Switch) {
 	tsr.Switch = sw
 }
@@ -140,7 +230,9 @@ func (tsr *TssReactor) GetChannels() []*conn.ChannelDescriptor {
 func (tsr *TssReactor) AddPeer(peer p2p.Peer) {}
 
 //todo: resharing?
-func (tsr *TssReactor) RemovePeer(peer p2p.Peer, reason interface{}) {}
+func (tsr *TssReactor) RemovePeer(peer p2p.Peer, reason interface{}) {
+	tsr.trustStore.PeerDisconnected(string(peer.ID()))
+}
 
 func (tsr *TssReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	msg, err := decodeMsg(msgBytes)
@@ -148,6 +240,15 @@ func (tsr *TssReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 		tsr.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
 		return
 	}
+	if err := tsr.authenticateMsg(src.ID(), &msg); err != nil {
+		tsr.rejectMsg(src, msg, err)
+		return
+	}
+
+	if msg.PmsgType == ResharingMsg {
+		tsr.receiveResharing(src, msg)
+		return
+	}
 
 	pMsg, err := tss.ParseWireMessage(msg.Pmsg, msg.From, msg.Isbroadcast)
 	if err != nil {
@@ -157,20 +258,48 @@ func (tsr *TssReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	tsr.Logger.Debug("receive msg", "from", msg.From.Id, "me", tsr.localAddr)
 	if msg.PmsgType == KeygenMsg {
 		//no keygen
-		if !tsr.keygenRSwitch[msg.Sid] {
-			tsr.Keygen(msg.Threshold, msg.Sid)
+		if tsr.joinable(msg.Sid) {
+			if _, err := tsr.keygen(context.Background(), msg.KeyType, msg.Curve, msg.Threshold, msg.KeyID, msg.Sid); !joined(err) {
+				tsr.Logger.Error("keygen err", "error", err)
+				return
+			}
 		}
-		_, err := tsr.localParty[msg.Sid].Update(pMsg)
-		if err != nil {
-			tsr.keygenCh[msg.Sid].errCh <- err
+		if err := tsr.deliver(msg.Sid, inboxMsg{pMsg: pMsg}); err != nil {
+			tsr.rejectMsg(src, msg, err)
+		}
+		return
+	} else if msg.PmsgType == SigningMsg && len(msg.Msgs) != 0 {
+		// presignatures are only taken on a msg of their owner, the msgs of
+		// the other parties wait for it
+		if len(msg.Presigs) != 0 && (len(msg.Parties) == 0 || msg.Parties[0] != string(src.ID())) &&
+			tsr.sessions.hold(msg.Sid, inboxMsg{pMsg: pMsg}) {
+			return
+		}
+		if tsr.joinable(msg.Sid) {
+			if _, err := tsr.batchSigning(context.Background(), msg.Msgs, msg.KeyID, msg.Sid, msg.Parties, msg.Path, msg.Presigs, nil); !joined(err) {
+				tsr.Logger.Error("batch signing err", "error", err)
+				return
+			}
+		}
+		if err := tsr.deliver(msg.Sid, inboxMsg{pMsg: pMsg}); err != nil {
+			tsr.rejectMsg(src, msg, err)
+		}
+		return
+	} else if msg.PmsgType == PresignMsg {
+		if tsr.joinable(msg.Sid) {
+			if _, err := tsr.presign(context.Background(), msg.KeyID, msg.Sid, msg.Parties, msg.Count); !joined(err) {
+				tsr.Logger.Error("presigning err", "error", err)
+				return
+			}
+		}
+		if err := tsr.deliver(msg.Sid, inboxMsg{pMsg: pMsg}); err != nil {
+			tsr.rejectMsg(src, msg, err)
 		}
 		return
 	} else if msg.PmsgType == SigningMsg {
 		//keygen done, do signing
-		if !tsr.signingRSwitch[msg.Sid] {
-			x := new(big.Int)
-			x.SetBytes(msg.Msg)
-			if _, err := tsr.Signing(x, msg.Sid); err != nil {
+		if tsr.joinable(msg.Sid) {
+			if _, err := tsr.signing(context.Background(), msg.Msg, msg.KeyID, msg.Sid, msg.Parties, msg.Path, msg.Taproot); !joined(err) {
 				tsr.Logger.Error("signing err", "error", err)
 				return
 			}
@@ -186,220 +315,467 @@ func (tsr *TssReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 		//		return
 		//	}
 		//}
-		ok, err := tsr.localParty[msg.Sid].Update(pMsg)
-		if err != nil {
-			tsr.signingCh[msg.Sid].errCh <- err
-		}
-		time.Sleep(2 * time.Second)
-		if ok {
-			tsr.Logger.Debug("receive success", "local round", tsr.localParty[msg.Sid].(*signing.LocalParty).BaseParty.String(), "me", tsr.localAddr)
-		} else {
-			tsr.Logger.Debug("update response not ok")
+		if err := tsr.deliver(msg.Sid, inboxMsg{pMsg: pMsg}); err != nil {
+			tsr.rejectMsg(src, msg, err)
 		}
 		return
 	}
 }
 
+// joinable reports whether a msg of session sid should start the session on
+// this node.
+func (tsr *TssReactor) joinable(sid SessionID) bool {
+	return tsr.sessions.get(sid) == nil && !tsr.sessions.hasEnded(sid)
+}
+
+// joined reports whether joining a session succeeded, or another msg of the
+// session got there first.
+func joined(err error) bool {
+	return err == nil || err == errSessionExists || err == errSessionEnded
+}
+
+// deliver queues msg on the inbox of session sid. Msgs of a finished session
+// are dropped, msgs from a party that is not in the session are refused.
+func (tsr *TssReactor) deliver(sid SessionID, msg inboxMsg) error {
+	s := tsr.sessions.get(sid)
+	if s == nil {
+		tsr.Logger.Debug("drop msg of a finished session", "sid", sid, "from", msg.pMsg.GetFrom().Id)
+		return nil
+	}
+	if !s.isParty(msg.pMsg.GetFrom()) {
+		return fmt.Errorf("party %s is not in session %s", msg.pMsg.GetFrom(), sid)
+	}
+	s.inbox.push(msg)
+	return nil
+}
+
+// rejectMsg drops msg of src, a peer sending msgs it may not send loses trust.
+func (tsr *TssReactor) rejectMsg(src p2p.Peer, msg TssMessage, err error) {
+	tsr.Logger.Error("reject msg", "src", src.ID(), "key", msg.KeyID, "sid", msg.Sid, "type", msg.PmsgType, "err", err)
+	tsr.recordBadPeer(string(src.ID()), err.Error())
+}
+
 func (tsr *TssReactor) InitPeer(peer p2p.Peer) p2p.Peer {
 	return peer
 }
 
-//keygen initiator function
-func (tsr *TssReactor) Keygen(threshold int, sid SessionID) chan struct{}{
-	if 	tsr.localParty[sid] != nil {
-		tsr.Logger.Error("sid exists")
-		return nil
+//keygen initiator function, generates a new secret of req.KeyType stored under
+//req.KeyID. It blocks until the keygen is done, ctx is done or the session
+//times out.
+func (tsr *TssReactor) Keygen(ctx context.Context, req KeygenRequest) *KeygenResult {
+	if req.Threshold == 0 {
+		req.Threshold = tsr.tssConfig.Threshold
+	}
+	resCh, err := tsr.keygen(ctx, req.KeyType, req.Curve, req.Threshold, req.KeyID, newSessionID())
+	if err != nil {
+		return &KeygenResult{KeyID: req.KeyID, Err: tss.NewError(err, keygen.TaskName, -1, nil)}
+	}
+	return <-resCh
+}
+
+func (tsr *TssReactor) keygen(ctx context.Context, keyType KeyType, curve tss.CurveName, threshold int, keyID KeyID, sid SessionID) (chan *KeygenResult, error) {
+	// the share could not be stored at the end
+	if tsr.keyring.locked() {
+		return nil, ErrStoreLocked
+	}
+	if err := keyType.validate(); err != nil {
+		return nil, err
+	}
+	keyType = keyType.normalized()
+	ec, err := keyType.curve(curve)
+	if err != nil {
+		return nil, err
+	}
+	curve = curveName(ec)
+	if tsr.tssStore.Has(tsr.newPrefixKey(SaveDataKey, keyID)) {
+		return nil, fmt.Errorf("key %s exists", keyID)
+	}
+	if threshold < 1 || threshold >= len(tsr.peers) {
+		return nil, fmt.Errorf("invalid threshold %d for %d peers", threshold, len(tsr.peers))
+	}
+	if err := tsr.checkTrusted(tsr.peers); err != nil {
+		return nil, err
 	}
 
 	//prepare keygen params
 	pIDs := generatePIDs(tsr.peers)
 	p2pCtx := tss.NewPeerContext(pIDs)
 
-	var partyIndex int
-	for i := range pIDs {
-		if pIDs[i].Id == tsr.localAddr {
-			partyIndex = i
-			break
-		}
+	partyIndex := findPartyIndex(pIDs, tsr.localAddr)
+	if partyIndex < 0 {
+		return nil, errors.New("local node is not in the persistent peers")
 	}
-	keygenCh := struct {
-		errCh chan *tss.Error
-		outCh chan tss.Message
-		endCh chan keygen.LocalPartySaveData
-	}{
-		errCh: make(chan *tss.Error),
-		outCh: make(chan tss.Message),
-		endCh: make(chan keygen.LocalPartySaveData),
+	// add the session first, a session joined twice must not take pre-params
+	ctx, cancel := context.WithTimeout(ctx, tsr.sessions.timeout)
+	s := &session{keyID: keyID, exclusive: true, pIDs: pIDs, inbox: newSessionInbox(), cancel: cancel}
+	if err := tsr.sessions.add(sid, s); err != nil {
+		cancel()
+		return nil, err
 	}
-
-	tsr.keygenCh[sid] = keygenCh
-
-	params := tss.NewParameters(p2pCtx, pIDs[partyIndex], len(pIDs), threshold)
-	localParty := keygen.NewLocalParty(params, tsr.keygenCh[sid].outCh, tsr.keygenCh[sid].endCh).(*keygen.LocalParty)
-	tsr.localParty[sid] = localParty
-
-	go func(P *keygen.LocalParty) {
-		if err := P.Start(); err != nil {
-			tsr.keygenCh[sid].errCh <- err
+	keygenCh := newKeygenChannels(len(pIDs))
+	params := tss.NewParameters(ec, p2pCtx, pIDs[partyIndex], len(pIDs), threshold)
+	var localParty tss.Party
+	switch keyType {
+	case KeyTypeEdDSA:
+		// EdDSA and Schnorr have no paillier keys, they need no pre-params
+		localParty = eddsakeygen.NewLocalParty(params, keygenCh.outCh, keygenCh.eddsaEndCh)
+	case KeyTypeSchnorr:
+		localParty = frostkeygen.NewLocalParty(params, keygenCh.outCh, keygenCh.eddsaEndCh)
+	default:
+		optionalPreParams, err := tsr.takePreParams()
+		if err != nil {
+			tsr.sessions.end(sid)
+			return nil, err
 		}
-	}(localParty)
+		localParty = keygen.NewLocalParty(params, keygenCh.outCh, keygenCh.endCh, optionalPreParams...)
+	}
 
-	resCh := make(chan struct{})
-	go tsr.keygenRoutine(partyIndex, localParty, pIDs, sid, threshold, resCh)
-	return resCh
+	go tsr.inboxRoutine(ctx, s.inbox, []tss.Party{localParty}, func(inboxMsg) tss.Party { return localParty }, keygenCh.errCh)
+
+	resCh := make(chan *KeygenResult, 1)
+	go tsr.keygenRoutine(ctx, localParty, keyType, curve, pIDs, keyID, sid, threshold, keygenCh, resCh)
+	return resCh, nil
 }
 
-func (tsr *TssReactor) keygenRoutine(partyIndex int, party *keygen.LocalParty, pIDs tss.SortedPartyIDs, sid SessionID, threshold int, resCh chan struct{}) {
-	if tsr.keygenRSwitch[sid] {
-		return
+// takePreParams returns the pre-params for a keygen party, none if there are
+// no pre-params ready.
+func (tsr *TssReactor) takePreParams() ([]keygen.LocalPreParams, error) {
+	if tsr.preParams == nil {
+		return nil, nil
 	}
-	tsr.keygenRSwitch[sid] = true
-	defer func() {
-		tsr.keygenRSwitch[sid] = false
-	}()
+	preParams, err := tsr.preParams()
+	if err != nil {
+		return nil, err
+	}
+	if preParams == nil {
+		tsr.Logger.Info("no pre-params ready, the party generates them")
+		return nil, nil
+	}
+	return []keygen.LocalPreParams{*preParams}, nil
+}
 
+func (tsr *TssReactor) keygenRoutine(ctx context.Context, party tss.Party, keyType KeyType, curve tss.CurveName, pIDs tss.SortedPartyIDs, keyID KeyID, sid SessionID, threshold int, ch keygenChannels, resCh chan *KeygenResult) {
+	defer tsr.sessions.end(sid)
+
+	fail := func(err *tss.Error) {
+		tsr.Logger.Error("keygen error", "key", keyID, "sid", sid, "err", err, "culprits", err.Culprits())
+		tsr.recordCulprits(err)
+		resCh <- &KeygenResult{KeyID: keyID, Err: err}
+	}
 	for {
 		select {
-		case err := <- tsr.keygenCh[sid].errCh:
-			tsr.Logger.Error("keygen error", err)
-			close(resCh)
+		case <-ctx.Done():
+			// the parties we still wait for are the ones that hang
+			fail(party.WrapError(ctx.Err(), tsr.waitingForPeers(ctx, party)...))
+			return
+
+		case err := <-ch.errCh:
+			fail(err)
 			return
 
-		case msg := <- tsr.keygenCh[sid].outCh:
+		case msg := <- ch.outCh:
 			dest := msg.GetTo()
 			if dest == nil { // broadcast
 				for _, id := range tsr.peers {
 					if id == msg.GetFrom().Id {
 						continue
 					}
-					tsr.TrySendByPeerID(party, sid, id, threshold, msg, KeygenMsg, nil, tsr.keygenCh[sid].errCh)
+					if err := tsr.TrySendByPeerID(party, keyType, curve, keyID, sid, id, threshold, msg, KeygenMsg, nil, nil, nil, nil); err != nil {
+						fail(err)
+						return
+					}
 				}
 			} else { // point-to-point
 				if dest[0].Id == msg.GetFrom().Id {
-					tsr.Logger.Error("msg error", "Error: %s", errors.New(fmt.Sprintf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)))
+					fail(party.WrapError(fmt.Errorf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)))
+					return
+				}
+				if err := tsr.TrySendByPeerID(party, keyType, curve, keyID, sid, dest[0].Id, threshold, msg, KeygenMsg, nil, nil, nil, nil); err != nil {
+					fail(err)
 					return
 				}
-				tsr.TrySendByPeerID(party, sid, dest[0].Id, threshold, msg, KeygenMsg, nil, tsr.keygenCh[sid].errCh)
 			}
 
-		case save := <- tsr.keygenCh[sid].endCh:
-			tryWriteTestFixtureFile(partyIndex, save)
-			saveData := SaveData{
-				PartySaveData:  &PartySaveData{
-					LocalPartySaveData: save,
-					SortedPartyIDs:     pIDs,
-				},
-				ConfigSaveData: &ConfigSaveData{
-					LocalAddr: tsr.localAddr,
-					Peers:     tsr.peers,
-					Thresold:  threshold,
-				},
-				//KeygenParty:    party,
-				//SigningParty:   nil,
-				//ResharingParty: nil,
+		case save := <- ch.endCh:
+			tsr.keygenDone(party, keyID, sid, threshold, &PartySaveData{
+				KeyType:            keyType,
+				LocalPartySaveData: save,
+				SortedPartyIDs:     pIDs,
+				ChainCode:          newChainCode(save.BigXj),
+			}, fail, resCh)
+			return
+
+		case save := <-ch.eddsaEndCh:
+			saveData := &PartySaveData{KeyType: keyType, SortedPartyIDs: pIDs}
+			if keyType == KeyTypeSchnorr {
+				schnorrSave := frostkeygen.NewSaveData(save)
+				saveData.SchnorrSaveData = &schnorrSave
+			} else {
+				saveData.EdDSASaveData = &save
 			}
-			tsr.saveDatas[sid] = saveData
-			psd, _ := json.Marshal(saveData)
-			tsr.tssStore.Set(tsr.newPrefixKey(SaveDataKey, sid), psd)
-			tsr.Logger.Info(fmt.Sprintf("sid: %s:keygen done", sid))
-			close(resCh)
+			tsr.keygenDone(party, keyID, sid, threshold, saveData, fail, resCh)
 			return
 		}
 	}
 }
 
-//signing initiator function
-func (tsr *TssReactor) Signing(msg *big.Int, sid SessionID) (chan common.SignatureData, error) {
-	if err := tsr.getSaveData(sid); err != nil {
+// keygenDone stores the share of a finished keygen.
+func (tsr *TssReactor) keygenDone(party tss.Party, keyID KeyID, sid SessionID, threshold int, partySaveData *PartySaveData, fail func(*tss.Error), resCh chan *KeygenResult) {
+	saveData := SaveData{
+		PartySaveData:  partySaveData,
+		ConfigSaveData: &ConfigSaveData{
+			LocalAddr: tsr.localAddr,
+			Peers:     tsr.peers,
+			Thresold:  threshold,
+		},
+	}
+	if err := tsr.setSaveData(keyID, saveData); err != nil {
+		fail(party.WrapError(err))
+		return
+	}
+	tsr.updateKeyInfo(keyID, saveData)
+	tsr.Logger.Info(fmt.Sprintf("key: %s sid: %s:keygen done", keyID, sid))
+	tsr.recordGoodParties(tsr.peers)
+	resCh <- &KeygenResult{KeyID: keyID, KeyType: partySaveData.keyType(), Curve: curveName(partySaveData.share().ec), PubKey: partySaveData.pubKey()}
+}
+
+//signing initiator function, signs req.Msg with the key of req.KeyID. Each call
+//runs in its own session, so a key may be used by several signings at once. An
+//ECDSA key signs with a presignature of this node if it has one left. It
+//blocks until the signature is done, ctx is done or the session times out.
+func (tsr *TssReactor) Sign(ctx context.Context, req SignRequest) *SignResult {
+	msg := req.Data
+	if msg == nil {
+		digest, err := digestOf(req.Msg)
+		if err != nil {
+			return &SignResult{KeyID: req.KeyID, HashAlg: req.HashAlg, Err: tss.NewError(err, signing.TaskName, -1, nil)}
+		}
+		msg = digest[:]
+	}
+	if req.Taproot == nil && len(req.Path) == 0 && checkDigests([][]byte{msg}) == nil {
+		presigIDs, presigs, parties, err := tsr.takePresignatures(req.KeyID, req.Parties, 1)
+		if err != nil {
+			tsr.Logger.Error("take presignatures failed", "key", req.KeyID, "err", err)
+		}
+		if presigIDs != nil {
+			return tsr.signBatch(ctx, req.KeyID, [][]byte{msg}, req.HashAlg, parties, req.Path, presigIDs, presigs)[0]
+		}
+	}
+	resCh, err := tsr.signing(ctx, msg, req.KeyID, newSessionID(), req.Parties, req.Path, req.Taproot)
+	if err != nil {
+		return &SignResult{KeyID: req.KeyID, HashAlg: req.HashAlg, Err: tss.NewError(err, signing.TaskName, -1, nil)}
+	}
+	res := <-resCh
+	res.HashAlg = req.HashAlg
+	return res
+}
+
+func (tsr *TssReactor) signing(ctx context.Context, msg []byte, keyID KeyID, sid SessionID, parties []string, path []uint32, taproot *TaprootTweak) (chan *SignResult, error) {
+	if err := tsr.checkNotRetired(keyID); err != nil {
 		return nil, err
 	}
-	if err := tsr.validateSaveData(sid); err != nil {
+	saveData, err := tsr.getSaveData(keyID)
+	if err != nil {
 		return nil, err
 	}
+	if err := tsr.validateSaveData(saveData); err != nil {
+		return nil, err
+	}
+	keyType := saveData.PartySaveData.keyType()
+	if keyType == KeyTypeECDSA {
+		// ECDSA signs a digest, kept with its leading zero bytes
+		if len(msg) > 32 {
+			return nil, errors.New("msg is not a 32 byte digest")
+		}
+		var digest [32]byte
+		copy(digest[32-len(msg):], msg)
+		msg = digest[:]
+	} else if len(path) != 0 {
+		return nil, errNoChildren
+	}
+	if taproot != nil && keyType != KeyTypeSchnorr {
+		return nil, errors.New("only Schnorr keys sign for taproot outputs")
+	}
 
-	signPIDs := tsr.saveDatas[sid].PartySaveData.SortedPartyIDs
-	p2pCtx := tss.NewPeerContext(signPIDs)
-
-	signingCh := struct {
-		errCh chan *tss.Error
-		outCh chan tss.Message
-		endCh chan common.SignatureData
-	}{
-		errCh: make(chan *tss.Error),
-		outCh: make(chan tss.Message),
-		endCh: make(chan common.SignatureData),
+	//no quorum given, pick threshold+1 parties from the connected peers
+	if len(parties) == 0 {
+		quorum, err := tsr.selectQuorum(saveData)
+		if err != nil {
+			return nil, err
+		}
+		parties = quorum
+	}
+	if err := tsr.validateQuorum(saveData, parties); err != nil {
+		return nil, err
+	}
+	if err := tsr.checkTrusted(parties); err != nil {
+		return nil, err
 	}
 
-	tsr.signingCh[sid] = signingCh
+	signPIDs := subsetPIDs(saveData.PartySaveData.SortedPartyIDs, parties)
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	partyIndex := findPartyIndex(signPIDs, tsr.localAddr)
 
-	var partyIndex int
-	for i := range signPIDs {
-		if signPIDs[i].Id == tsr.localAddr {
-			partyIndex = i
-			break
+	signingCh := newSigningChannels(len(signPIDs))
+	params := tss.NewParameters(saveData.PartySaveData.share().ec, p2pCtx, signPIDs[partyIndex], len(signPIDs), saveData.ConfigSaveData.Thresold)
+	var localParty tss.Party
+	switch keyType {
+	case KeyTypeEdDSA:
+		localParty = eddsasigning.NewLocalParty(msg, params, *saveData.PartySaveData.EdDSASaveData, signingCh.outCh, signingCh.endCh)
+	case KeyTypeSchnorr:
+		// a taproot output signs with the share tweaked by the output key tweak
+		key := *saveData.PartySaveData.SchnorrSaveData
+		if taproot != nil {
+			if key, err = frostsigning.TaprootKey(key, taproot.MerkleRoot); err != nil {
+				return nil, err
+			}
 		}
+		localParty = frostsigning.NewLocalParty(msg, params, key, signingCh.outCh, signingCh.endCh)
+	default:
+		// a child key signs with the share tweaked by its offset
+		key, err := tweakKey(saveData.PartySaveData.LocalPartySaveData, chainCodeOf(saveData), path)
+		if err != nil {
+			return nil, err
+		}
+		localParty = signing.NewLocalParty(new(big.Int).SetBytes(msg), params, key, signingCh.outCh, signingCh.endCh)
 	}
 
-	params := tss.NewParameters(p2pCtx, signPIDs[partyIndex], len(signPIDs), tsr.saveDatas[sid].ConfigSaveData.Thresold)
-	localParty := signing.NewLocalParty(msg, params, tsr.saveDatas[sid].PartySaveData.LocalPartySaveData, tsr.signingCh[sid].outCh, tsr.signingCh[sid].endCh).(*signing.LocalParty)
-	tsr.localParty[sid] = localParty
+	ctx, cancel := context.WithTimeout(ctx, tsr.sessions.timeout)
+	s := &session{keyID: keyID, pIDs: signPIDs, inbox: newSessionInbox(), cancel: cancel}
+	if err := tsr.sessions.add(sid, s); err != nil {
+		cancel()
+		return nil, err
+	}
 
-	go func(P *signing.LocalParty) {
-		if err := P.Start(); err != nil {
-			tsr.signingCh[sid].errCh <- err
-		}
-	}(localParty)
+	go tsr.inboxRoutine(ctx, s.inbox, []tss.Party{localParty}, func(inboxMsg) tss.Party { return localParty }, signingCh.errCh)
 
-	resCh := make(chan common.SignatureData)
-	go tsr.signingRoutine(msg, localParty, sid, resCh)
+	// buffered, parties that joined the session never read the result
+	resCh := make(chan *SignResult, 1)
+	go tsr.signingRoutine(ctx, msg, localParty, keyID, sid, saveData.ConfigSaveData.Thresold, peersOf(signPIDs), path, taproot, signingCh, resCh)
 	return resCh, nil
 }
 
-func (tsr *TssReactor) signingRoutine(msg *big.Int, party *signing.LocalParty, sid SessionID, resCh chan common.SignatureData) {
-	if tsr.signingRSwitch[sid] {
-		return
+// selectQuorum picks the local node and the first connected members of the
+// committee the policy does not refuse, threshold+1 parties in total.
+func (tsr *TssReactor) selectQuorum(saveData SaveData) ([]string, error) {
+	threshold := saveData.ConfigSaveData.Thresold
+	quorum := []string{tsr.localAddr}
+	for _, id := range saveData.ConfigSaveData.Peers {
+		if len(quorum) == threshold+1 {
+			break
+		}
+		if id != tsr.localAddr && tsr.Switch.Peers().Has(p2p.ID(id)) && tsr.checkTrusted([]string{id}) == nil {
+			quorum = append(quorum, id)
+		}
+	}
+	if len(quorum) < threshold+1 {
+		return nil, fmt.Errorf("only %d parties online, signing needs threshold+1=%d", len(quorum), threshold+1)
+	}
+	return quorum, nil
+}
+
+func (tsr *TssReactor) validateQuorum(saveData SaveData, parties []string) error {
+	threshold := saveData.ConfigSaveData.Thresold
+	if len(parties) < threshold+1 {
+		return fmt.Errorf("signing needs threshold+1=%d parties, got %d", threshold+1, len(parties))
+	}
+	if !containsPeer(parties, tsr.localAddr) {
+		return errors.New("local node is not in the signing parties")
 	}
-	tsr.signingRSwitch[sid] = true
-	defer func() {
-		tsr.signingRSwitch[sid] = false
-	}()
+	seen := make(map[string]bool, len(parties))
+	for _, id := range parties {
+		if seen[id] {
+			return fmt.Errorf("duplicate peer %s in the signing parties", id)
+		}
+		seen[id] = true
+		if !containsPeer(saveData.ConfigSaveData.Peers, id) {
+			return fmt.Errorf("peer %s does not hold a share of this key", id)
+		}
+		if id != tsr.localAddr && !tsr.Switch.Peers().Has(p2p.ID(id)) {
+			return fmt.Errorf("peer %s of the signing parties is not connected", id)
+		}
+	}
+	return nil
+}
 
+func (tsr *TssReactor) signingRoutine(ctx context.Context, msg []byte, party tss.Party, keyID KeyID, sid SessionID, threshold int, parties []string, path []uint32, taproot *TaprootTweak, ch signingChannels, resCh chan *SignResult) {
+	defer tsr.sessions.end(sid)
+
+	fail := func(err *tss.Error) {
+		tsr.Logger.Error("signing err", "key", keyID, "sid", sid, "err", err, "culprits", err.Culprits())
+		tsr.recordCulprits(err)
+		resCh <- &SignResult{KeyID: keyID, Err: err}
+	}
 	for {
 		select {
-		case err := <-tsr.signingCh[sid].errCh:
-			tsr.Logger.Error("signing err", err)
+		case <-ctx.Done():
+			// the parties we still wait for are the ones that hang
+			fail(party.WrapError(ctx.Err(), tsr.waitingForPeers(ctx, party)...))
 			return
 
-		case pmsg := <-tsr.signingCh[sid].outCh:
+		case err := <-ch.errCh:
+			fail(err)
+			return
+
+		case pmsg := <-ch.outCh:
 			dest := pmsg.GetTo()
 			if dest == nil {
-				for _, id := range tsr.peers {
+				for _, id := range parties {
 					if id == pmsg.GetFrom().Id {
 						continue
 					}
-					tsr.TrySendByPeerID(party, sid, id, tsr.saveDatas[sid].ConfigSaveData.Thresold, pmsg, SigningMsg, msg, tsr.signingCh[sid].errCh)
+					if err := tsr.TrySendByPeerID(party, "", "", keyID, sid, id, threshold, pmsg, SigningMsg, msg, parties, path, taproot); err != nil {
+						fail(err)
+						return
+					}
 				}
 			} else {
 				if dest[0].Id == pmsg.GetFrom().Id {
-					tsr.Logger.Error("msg error", "Error: %s", errors.New(fmt.Sprintf("party %d tried to send a message to itself (%d)", dest[0].Index, pmsg.GetFrom().Index)))
+					fail(party.WrapError(fmt.Errorf("party %d tried to send a message to itself (%d)", dest[0].Index, pmsg.GetFrom().Index)))
+					return
+				}
+				if err := tsr.TrySendByPeerID(party, "", "", keyID, sid, dest[0].Id, threshold, pmsg, SigningMsg, msg, parties, path, taproot); err != nil {
+					fail(err)
 					return
 				}
-				tsr.TrySendByPeerID(party, sid, dest[0].Id, tsr.saveDatas[sid].ConfigSaveData.Thresold, pmsg, SigningMsg, msg, tsr.signingCh[sid].errCh)
 			}
 
-		case signature := <-tsr.signingCh[sid].endCh:
+		case signature := <-ch.endCh:
 			tsr.Logger.Info(fmt.Sprintf("Done. Received signature data"))
-			resCh <- signature
+			// M keeps the leading zero bytes of an ECDSA digest
+			signature.M = msg
+			tsr.touchKey(keyID)
+			tsr.recordGoodParties(parties)
+			resCh <- &SignResult{KeyID: keyID, Signature: &signature}
 			return
 		}
 	}
 }
 
 
-func (tsr *TssReactor) Verify(msg *big.Int, sid SessionID, signature common.SignatureData) error{
-	if err := tsr.getSaveData(sid); err != nil {
+func (tsr *TssReactor) Verify(msg *big.Int, keyID KeyID, signature common.SignatureData) error{
+	digest, err := digestOf(msg)
+	if err != nil {
 		return err
 	}
+	return tsr.VerifyDigest(keyID, digest, signature)
+}
+
+// VerifyDigest checks signature of the 32 byte digest with the key of keyID.
+// A signature recording its digest in M must record this one. An EdDSA or
+// Schnorr key verifies the digest as its message.
+func (tsr *TssReactor) VerifyDigest(keyID KeyID, digest [32]byte, signature common.SignatureData) error {
+	saveData, err := tsr.getSaveData(keyID)
+	if err != nil {
+		return err
+	}
+	switch saveData.PartySaveData.keyType() {
+	case KeyTypeEdDSA, KeyTypeSchnorr:
+		return tsr.verifyMessage(saveData, digest[:], signature)
+	}
+	if len(signature.M) != 0 && new(big.Int).SetBytes(signature.M).Cmp(new(big.Int).SetBytes(digest[:])) != 0 {
+		return errors.New("signature is of another digest")
+	}
 
 	sigr := signature.R
 	sigR := new(big.Int)
@@ -410,13 +786,13 @@ func (tsr *TssReactor) Verify(msg *big.Int, sid SessionID, signature common.Sign
 	sumS.SetBytes(sums)
 
 	// BEGIN ECDSA verify
-	pkX, pkY := tsr.saveDatas[sid].PartySaveData.LocalPartySaveData.ECDSAPub.X(), tsr.saveDatas[sid].PartySaveData.LocalPartySaveData.ECDSAPub.Y()
+	pkX, pkY := saveData.PartySaveData.LocalPartySaveData.ECDSAPub.X(), saveData.PartySaveData.LocalPartySaveData.ECDSAPub.Y()
 	pk := ecdsa.PublicKey{
-		Curve: tss.EC(),
+		Curve: saveData.PartySaveData.LocalPartySaveData.ECDSAPub.Curve(),
 		X:     pkX,
 		Y:     pkY,
 	}
-	ok := ecdsa.Verify(&pk, msg.Bytes(), sigR, sumS)
+	ok := ecdsa.Verify(&pk, digest[:], sigR, sumS)
 	if !ok {
 		err := errors.New("ECDSA verify failed.")
 		tsr.Logger.Error(err.Error())
@@ -427,20 +803,355 @@ func (tsr *TssReactor) Verify(msg *big.Int, sid SessionID, signature common.Sign
 	return nil
 }
 
-//todo: resharing initiator function
-func (tsr *TssReactor) Resharing() error {
-	return nil
+//resharing initiator function, moves the key of keyID to the newPeers committee
+func (tsr *TssReactor) Resharing(keyID KeyID, newPeers []string, newThreshold int) (chan *ResharingResult, error) {
+	if tsr.sessions.keyBusy(keyID) {
+		return nil, errors.New("resharing of this key is in progress")
+	}
+	saveData, err := tsr.getSaveData(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if newThreshold < 1 || newThreshold >= len(newPeers) {
+		return nil, fmt.Errorf("invalid new threshold %d for %d new peers", newThreshold, len(newPeers))
+	}
+	oldPIDs := saveData.PartySaveData.SortedPartyIDs
+	seen := make(map[string]bool, len(newPeers))
+	for _, id := range newPeers {
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate peer %s in the new committee", id)
+		}
+		seen[id] = true
+	}
+	if err := tsr.checkNewCommittee(newPeers); err != nil {
+		return nil, err
+	}
+	for _, id := range append(peersOf(oldPIDs), newPeers...) {
+		if id != tsr.localAddr && tsr.Switch.Peers().Get(p2p.ID(id)) == nil {
+			return nil, fmt.Errorf("peer %s of the resharing committees is not connected", id)
+		}
+	}
+
+	tssmsg := &TssMessage{
+		KeyID:        keyID,
+		Sid:          newSessionID(),
+		Threshold:    saveData.ConfigSaveData.Thresold,
+		PmsgType:     ResharingMsg,
+		OldParties:   oldPIDs,
+		NewParties:   generateResharingPIDs(newPeers),
+		NewThreshold: newThreshold,
+		KeyType:      saveData.PartySaveData.keyType(),
+		Curve:        curveName(saveData.PartySaveData.share().ec),
+		ChainCode:    chainCodeOf(saveData),
+	}
+	resCh, err := tsr.resharing(tssmsg)
+	if err != nil {
+		return nil, err
+	}
+	// announce the session, members of the old committee that are not in the
+	// new one would otherwise never hear about it
+	for _, id := range unionPeers(peersOf(tssmsg.OldParties), peersOf(tssmsg.NewParties)) {
+		if id == tsr.localAddr {
+			continue
+		}
+		if err := tsr.trySend(id, tssmsg); err != nil {
+			tsr.Logger.Error("announce resharing failed", "to", id, "err", err)
+		}
+	}
+	return resCh, nil
+}
+
+func (tsr *TssReactor) receiveResharing(src p2p.Peer, msg TssMessage) {
+	// announcements carry no party, the sender must be in one of the committees
+	if !containsPeer(unionPeers(peersOf(msg.OldParties), peersOf(msg.NewParties)), string(src.ID())) {
+		tsr.rejectMsg(src, msg, fmt.Errorf("peer %s is in neither resharing committee", src.ID()))
+		return
+	}
+	if tsr.joinable(msg.Sid) {
+		if _, err := tsr.resharing(&msg); !joined(err) {
+			tsr.Logger.Error("join resharing failed", "sid", msg.Sid, "src", src, "err", err)
+			return
+		}
+	}
+
+	// announcement only
+	if len(msg.Pmsg) == 0 {
+		return
+	}
+	pMsg, err := tss.ParseWireMessage(msg.Pmsg, msg.From, msg.Isbroadcast)
+	if err != nil {
+		tsr.Logger.Error("Error parseWire message", "err", err)
+		return
+	}
+	if err := tsr.deliver(msg.Sid, inboxMsg{pMsg: pMsg, toOld: msg.ToOldCommittee}); err != nil {
+		tsr.rejectMsg(src, msg, err)
+	}
+}
+
+// resharing starts the local parties of this node in the session described by tssmsg.
+// A node in both committees runs an old and a new party at the same time.
+func (tsr *TssReactor) resharing(tssmsg *TssMessage) (chan *ResharingResult, error) {
+	keyID, sid := tssmsg.KeyID, tssmsg.Sid
+	oldPIDs, newPIDs := tssmsg.OldParties, tssmsg.NewParties
+	oldIndex, newIndex := findPartyIndex(oldPIDs, tsr.localAddr), findPartyIndex(newPIDs, tsr.localAddr)
+	if oldIndex < 0 && newIndex < 0 {
+		return nil, errors.New("local node is in neither the old nor the new committee")
+	}
+	if tsr.keyring.locked() {
+		return nil, ErrStoreLocked
+	}
+	// the share of a new member could not be used, and it must not replace
+	// another share of the node under the same key id
+	if err := tsr.checkNewCommittee(peersOf(newPIDs)); err != nil {
+		return nil, err
+	}
+	if oldIndex < 0 && tsr.tssStore.Has(tsr.newPrefixKey(SaveDataKey, keyID)) {
+		return nil, fmt.Errorf("key %s exists", keyID)
+	}
+	if err := tsr.checkTrusted(unionPeers(peersOf(oldPIDs), peersOf(newPIDs))); err != nil {
+		return nil, err
+	}
+	if err := tssmsg.KeyType.validate(); err != nil {
+		return nil, err
+	}
+	keyType := tssmsg.KeyType.normalized()
+	if keyType == KeyTypeSchnorr {
+		return nil, errors.New("Schnorr keys cannot be reshared")
+	}
+	// a new member takes the curve and the chain code from the old committee
+	ec, err := keyType.curve(tssmsg.Curve)
+	if err != nil {
+		return nil, err
+	}
+	var key keygen.LocalPartySaveData
+	var eddsaKey eddsakeygen.LocalPartySaveData
+	chainCode := tssmsg.ChainCode
+	if oldIndex >= 0 {
+		saveData, err := tsr.getSaveData(keyID)
+		if err != nil {
+			return nil, err
+		}
+		if saveData.PartySaveData.keyType() != keyType {
+			return nil, fmt.Errorf("key %s is not of key type %s", keyID, keyType)
+		}
+		if saveData.PartySaveData.share().ec != ec {
+			return nil, fmt.Errorf("key %s is not on curve %s", keyID, curveName(ec))
+		}
+		// the old committee and threshold are the ones of the share, not the
+		// ones a peer claims
+		if tssmsg.Threshold != saveData.ConfigSaveData.Thresold {
+			return nil, fmt.Errorf("resharing msg has threshold %d, key %s has %d", tssmsg.Threshold, keyID, saveData.ConfigSaveData.Thresold)
+		}
+		if !samePartyIDs(oldPIDs, saveData.PartySaveData.SortedPartyIDs) {
+			return nil, fmt.Errorf("resharing msg has another old committee than key %s", keyID)
+		}
+		if keyType == KeyTypeEdDSA {
+			if saveData.PartySaveData.EdDSASaveData == nil {
+				return nil, errors.New("incomplete save data")
+			}
+			eddsaKey = *saveData.PartySaveData.EdDSASaveData
+		}
+		key = saveData.PartySaveData.LocalPartySaveData
+		chainCode = chainCodeOf(saveData)
+	}
+	if keyType == KeyTypeECDSA && len(chainCode) != sha256.Size {
+		return nil, errors.New("resharing msg has no chain code")
+	}
+
+	// add the session first, a session joined twice must not take pre-params
+	ctx, cancel := context.WithTimeout(context.Background(), tsr.sessions.timeout)
+	s := &session{keyID: keyID, exclusive: true, pIDs: append(append(tss.SortedPartyIDs{}, oldPIDs...), newPIDs...), inbox: newSessionInbox(), cancel: cancel}
+	if err := tsr.sessions.add(sid, s); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resharingCh := newKeygenChannels(len(oldPIDs) + len(newPIDs))
+
+	oldCtx, newCtx := tss.NewPeerContext(oldPIDs), tss.NewPeerContext(newPIDs)
+	parties := make([]tss.Party, 0, 2)
+	var oldParty, newParty tss.Party
+	if oldIndex >= 0 {
+		params := tss.NewReSharingParameters(ec, oldCtx, newCtx, oldPIDs[oldIndex], len(oldPIDs), tssmsg.Threshold, len(newPIDs), tssmsg.NewThreshold)
+		if keyType == KeyTypeEdDSA {
+			oldParty = eddsaresharing.NewLocalParty(params, eddsaKey, resharingCh.outCh, resharingCh.eddsaEndCh)
+		} else {
+			oldParty = resharing.NewLocalParty(params, key, resharingCh.outCh, resharingCh.endCh)
+		}
+		parties = append(parties, oldParty)
+	}
+	if newIndex >= 0 && keyType == KeyTypeEdDSA {
+		params := tss.NewReSharingParameters(ec, oldCtx, newCtx, newPIDs[newIndex], len(oldPIDs), tssmsg.Threshold, len(newPIDs), tssmsg.NewThreshold)
+		newParty = eddsaresharing.NewLocalParty(params, eddsakeygen.NewLocalPartySaveData(len(newPIDs)), resharingCh.outCh, resharingCh.eddsaEndCh)
+		parties = append(parties, newParty)
+	} else if newIndex >= 0 {
+		save := keygen.NewLocalPartySaveData(len(newPIDs))
+		// re-use the pre-params of the old share, generating safe primes takes
+		// minutes. A new member takes them from the pool.
+		save.LocalPreParams = key.LocalPreParams
+		if oldIndex < 0 {
+			preParams, err := tsr.takePreParams()
+			if err != nil {
+				tsr.sessions.end(sid)
+				return nil, err
+			}
+			if len(preParams) > 0 {
+				save.LocalPreParams = preParams[0]
+			}
+		}
+		params := tss.NewReSharingParameters(ec, oldCtx, newCtx, newPIDs[newIndex], len(oldPIDs), tssmsg.Threshold, len(newPIDs), tssmsg.NewThreshold)
+		newParty = resharing.NewLocalParty(params, save, resharingCh.outCh, resharingCh.endCh)
+		parties = append(parties, newParty)
+	}
+
+	// buffered, parties that joined the session never read the result
+	resCh := make(chan *ResharingResult, 1)
+	go tsr.resharingRoutine(ctx, keyType, curveName(ec), keyID, sid, oldPIDs, newPIDs, tssmsg.Threshold, tssmsg.NewThreshold, chainCode, parties, resharingCh, resCh)
+	go tsr.inboxRoutine(ctx, s.inbox, parties, func(msg inboxMsg) tss.Party {
+		if msg.toOld {
+			return oldParty
+		}
+		return newParty
+	}, resharingCh.errCh)
+	return resCh, nil
 }
 
-func (tsr *TssReactor) TrySendByPeerID(party tss.Party, sid SessionID, pid string, threshold int, pmsg tss.Message, pmsgType msgType, msg *big.Int, errCh chan<- *tss.Error)  {
+func (tsr *TssReactor) resharingRoutine(ctx context.Context, keyType KeyType, curve tss.CurveName, keyID KeyID, sid SessionID, oldPIDs, newPIDs tss.SortedPartyIDs, threshold, newThreshold int, chainCode []byte, parties []tss.Party, ch keygenChannels, resCh chan *ResharingResult) {
+	defer tsr.sessions.end(sid)
+
+	fail := func(err *tss.Error) {
+		tsr.Logger.Error("resharing error", "key", keyID, "sid", sid, "err", err, "culprits", err.Culprits())
+		tsr.recordCulprits(err)
+		resCh <- &ResharingResult{KeyID: keyID, Err: err}
+	}
+	var newSave *PartySaveData
+	for ended := 0; ended < len(parties); {
+		select {
+		case <-ctx.Done():
+			// the parties we still wait for are the ones that hang
+			var culprits []*tss.PartyID
+			for _, P := range parties {
+				culprits = append(culprits, tsr.waitingForPeers(ctx, P)...)
+			}
+			fail(tss.NewError(ctx.Err(), resharing.TaskName, -1, nil, culprits...))
+			return
+
+		case err := <-ch.errCh:
+			fail(err)
+			return
+
+		case pmsg := <-ch.outCh:
+			tsr.routeResharingMsg(keyType, curve, keyID, sid, oldPIDs, newPIDs, threshold, newThreshold, chainCode, pmsg)
+
+		case save := <-ch.endCh:
+			ended++
+			// the old committee party ends with an empty save data
+			if save.Xi != nil {
+				newSave = &PartySaveData{KeyType: keyType, LocalPartySaveData: save, SortedPartyIDs: newPIDs, ChainCode: chainCode}
+			}
+
+		case save := <-ch.eddsaEndCh:
+			ended++
+			if save.Xi != nil {
+				newSave = &PartySaveData{KeyType: keyType, EdDSASaveData: &save, SortedPartyIDs: newPIDs}
+			}
+		}
+	}
+
+	if newSave != nil {
+		saveData := SaveData{
+			PartySaveData: newSave,
+			ConfigSaveData: &ConfigSaveData{
+				LocalAddr: tsr.localAddr,
+				Peers:     peersOf(newPIDs),
+				Thresold:  newThreshold,
+			},
+		}
+		// a single write replaces the old share
+		if err := tsr.setSaveData(keyID, saveData); err != nil {
+			fail(tss.NewError(err, resharing.TaskName, -1, nil))
+			return
+		}
+		tsr.updateKeyInfo(keyID, saveData)
+		// the presignatures are of the old share
+		tsr.dropPresignatures(keyID)
+	} else {
+		tsr.deleteKey(keyID)
+	}
+	tsr.Logger.Info(fmt.Sprintf("key: %s sid: %s:resharing done", keyID, sid))
+	tsr.recordGoodParties(unionPeers(peersOf(oldPIDs), peersOf(newPIDs)))
+	resCh <- &ResharingResult{KeyID: keyID}
+}
+
+func (tsr *TssReactor) routeResharingMsg(keyType KeyType, curve tss.CurveName, keyID KeyID, sid SessionID, oldPIDs, newPIDs tss.SortedPartyIDs, threshold, newThreshold int, chainCode []byte, pmsg tss.Message) {
+	dest := pmsg.GetTo()
+	var oldDest, newDest []*tss.PartyID
+	if pmsg.IsToOldAndNewCommittees() {
+		oldDest, newDest = dest[:len(oldPIDs)], dest[len(oldPIDs):]
+	} else if pmsg.IsToOldCommittee() {
+		oldDest = dest
+	} else {
+		newDest = dest
+	}
+
 	bz, _, err := pmsg.WireBytes()
 	if err != nil {
-		errCh <- party.WrapError(err)
+		tsr.Logger.Error("resharing msg error", "err", err)
 		return
 	}
+	send := func(to *tss.PartyID, toOld bool) {
+		if to.KeyInt().Cmp(pmsg.GetFrom().KeyInt()) == 0 {
+			return
+		}
+		// the other committee party of this node
+		if to.Id == tsr.localAddr {
+			pMsg, err := tss.ParseWireMessage(bz, pmsg.GetFrom(), pmsg.IsBroadcast())
+			if err != nil {
+				tsr.Logger.Error("Error parseWire message", "err", err)
+				return
+			}
+			if err := tsr.deliver(sid, inboxMsg{pMsg: pMsg, toOld: toOld}); err != nil {
+				tsr.Logger.Error("resharing msg error", "err", err)
+			}
+			return
+		}
+		tssmsg := &TssMessage{
+			KeyID:          keyID,
+			Sid:            sid,
+			Threshold:      threshold,
+			From:           pmsg.GetFrom(),
+			Isbroadcast:    pmsg.IsBroadcast(),
+			Pmsg:           bz,
+			PmsgType:       ResharingMsg,
+			OldParties:     oldPIDs,
+			NewParties:     newPIDs,
+			NewThreshold:   newThreshold,
+			ToOldCommittee: toOld,
+			KeyType:        keyType,
+			Curve:          curve,
+			ChainCode:      chainCode,
+		}
+		if err := tsr.trySend(to.Id, tssmsg); err != nil {
+			tsr.Logger.Error("send resharing msg failed", "to", to.Id, "err", err)
+		}
+	}
+	for _, to := range oldDest {
+		send(to, true)
+	}
+	for _, to := range newDest {
+		send(to, false)
+	}
+}
+
+func (tsr *TssReactor) TrySendByPeerID(party tss.Party, keyType KeyType, curve tss.CurveName, keyID KeyID, sid SessionID, pid string, threshold int, pmsg tss.Message, pmsgType msgType, msg []byte, parties []string, path []uint32, taproot *TaprootTweak) *tss.Error {
+	bz, _, err := pmsg.WireBytes()
+	if err != nil {
+		return party.WrapError(err)
+	}
 	from := pmsg.GetFrom()
 	isbroadcast := pmsg.IsBroadcast()
 	tssmsg := &TssMessage{
+		KeyID: keyID,
 		Sid: sid,
 		Isbroadcast: isbroadcast,
 		Threshold: threshold,
@@ -448,17 +1159,35 @@ func (tsr *TssReactor) TrySendByPeerID(party tss.Party, sid SessionID, pid strin
 		Pmsg:  bz,
 		PmsgType: pmsgType,
 	}
+	if pmsgType == KeygenMsg {
+		tssmsg.KeyType = keyType
+		tssmsg.Curve = curve
+	}
 	if pmsgType == SigningMsg{ // signing msg
-		tssmsg.Msg = msg.Bytes()
+		tssmsg.Msg = msg
+		tssmsg.Parties = parties
+		tssmsg.Path = path
+		tssmsg.Taproot = taproot
+	}
+	if err := tsr.trySend(pid, tssmsg); err != nil {
+		return party.WrapError(err, pmsg.GetTo()...)
+	}
+	return nil
+}
+
+func (tsr *TssReactor) trySend(pid string, tssmsg *TssMessage) error {
+	if tsr.tssConfig.SignMessages {
+		if err := tsr.signMsg(tssmsg); err != nil {
+			return err
+		}
 	}
 	tssbz, err := cdc.MarshalBinaryBare(tssmsg)
 	if err != nil {
-		errCh <- party.WrapError(err)
-		return
+		return err
 	}
 	src := tsr.Switch.Peers().Get(p2p.ID(pid))
 	if src == nil {
-		panic(fmt.Sprintf("cannot find this pid: %s in switch", pid))
+		return fmt.Errorf("cannot find this pid: %s in switch", pid)
 	}
  	queued := src.TrySend(TssChannel, tssbz)
 	for {
@@ -467,39 +1196,95 @@ func (tsr *TssReactor) TrySendByPeerID(party tss.Party, sid SessionID, pid strin
 			time.Sleep(1 * time.Second)
 			queued = src.TrySend(TssChannel, tssbz)
 		} else {
-			return
+			return nil
 		}
 	}
 }
 
-func (tsr *TssReactor) getSaveData(sid SessionID) error {
-	if psd := tsr.tssStore.Get(tsr.newPrefixKey(SaveDataKey, sid)); psd != nil {
-		var saveData SaveData
+// reportError hands err to a session routine without blocking, the routine
+// stops at the first error anyway.
+func reportError(errCh chan *tss.Error, err *tss.Error) {
+	select {
+	case errCh <- err:
+	default:
+	}
+}
+
+func (tsr *TssReactor) getSaveData(keyID KeyID) (SaveData, error) {
+	var saveData SaveData
+	key := tsr.newPrefixKey(SaveDataKey, keyID)
+	if psd := tsr.tssStore.Get(key); psd != nil {
 		//if err := cdc.UnmarshalBinaryBare(psd, &saveData); err != nil {
 		//	return err
 		//}
+		sealed := isSealed(psd)
+		if sealed {
+			var err error
+			if psd, err = tsr.keyring.open(key, psd); err != nil {
+				return saveData, err
+			}
+		}
 		if err := json.Unmarshal(psd, &saveData); err != nil {
-			return err
+			return saveData, err
+		}
+		// shares written before the store was encrypted are sealed on first use
+		if !sealed {
+			if err := tsr.setSaveData(keyID, saveData); err != nil {
+				return saveData, err
+			}
 		}
-		tsr.saveDatas[sid] = saveData
 		//tryWriteTestFixtureFile(rand.Int()+2, saveData.PartySaveData.LocalPartySaveData)
-		return nil
+		return saveData, nil
 	}
-	return errors.New("No SaveData")
+	return saveData, errors.New("No SaveData")
 }
 
-func (tsr *TssReactor) validateSaveData(sid SessionID) error {
-	if !reflect.DeepEqual(tsr.peers, tsr.saveDatas[sid].ConfigSaveData.Peers) {
-		return errors.New("Peers is not the same as the previous peers")
+// setSaveData seals saveData and stores it under keyID.
+func (tsr *TssReactor) setSaveData(keyID KeyID, saveData SaveData) error {
+	psd, err := json.Marshal(saveData)
+	if err != nil {
+		return err
+	}
+	key := tsr.newPrefixKey(SaveDataKey, keyID)
+	sealed, err := tsr.keyring.seal(key, psd)
+	if err != nil {
+		return err
+	}
+	tsr.tssStore.Set(key, sealed)
+	return nil
+}
+
+func (tsr *TssReactor) validateSaveData(saveData SaveData) error {
+	if saveData.PartySaveData == nil || saveData.ConfigSaveData == nil || saveData.PartySaveData.pubKey() == nil {
+		return errors.New("incomplete save data")
+	}
+	// the committee may differ from the persistent peers after a resharing,
+	// but every member still has to be one of them
+	for _, id := range saveData.ConfigSaveData.Peers {
+		if !containsPeer(tsr.peers, id) {
+			return fmt.Errorf("Peer %s of the committee is not in the persistent peers", id)
+		}
 	}
-	if tsr.localAddr != tsr.saveDatas[sid].ConfigSaveData.LocalAddr {
+	if tsr.localAddr != saveData.ConfigSaveData.LocalAddr {
 		return errors.New("LocalAddress is not the same as the previous localAddress")
 	}
 	return nil
 }
 
-func (tsr *TssReactor) newPrefixKey(prefix string, sid SessionID) []byte {
-	return []byte(prefix + string(sid))
+// checkNewCommittee returns an error naming the first member of a new
+// resharing committee that is not a persistent peer, the new share would not
+// pass validateSaveData.
+func (tsr *TssReactor) checkNewCommittee(newPeers []string) error {
+	for _, id := range newPeers {
+		if !containsPeer(tsr.peers, id) {
+			return fmt.Errorf("peer %s of the new committee is not in the persistent peers", id)
+		}
+	}
+	return nil
+}
+
+func (tsr *TssReactor) newPrefixKey(prefix string, keyID KeyID) []byte {
+	return []byte(prefix + string(keyID))
 }
 
 func decodeMsg(bz []byte) (msg TssMessage, err error) {
@@ -535,36 +1320,3 @@ func decodeMsg(bz []byte) (msg TssMessage, err error) {
 //	cdc.RegisterConcrete(&resharing.DGRound4Message{}, "tss/resharing/round1message", nil)
 //}
 //
-
-func tryWriteTestFixtureFile(index int, data keygen.LocalPartySaveData) {
-	fixtureFileName := makeTestFixtureFilePath(index)
-
-	// fixture file does not already exist?
-	// if it does, we won't re-create it here
-	fi, err := os.Stat(fixtureFileName)
-	if !(err == nil && fi != nil && !fi.IsDir()) {
-		fd, err := os.OpenFile(fixtureFileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
-		if err != nil {
-		}
-		bz, err := json.Marshal(&data)
-		if err != nil {
-		}
-		_, err = fd.Write(bz)
-		if err != nil {
-		}
-	} else {
-	}
-	//
-}
-
-const (
-	testFixtureDirFormat  = "%s/test"
-	testFixtureFileFormat = "keygen_data_%d.json"
-)
-
-func makeTestFixtureFilePath(partyIndex int) string {
-	_, callerFileName, _, _ := runtime.Caller(0)
-	srcDirName := filepath.Dir(callerFileName)
-	fixtureDirName := fmt.Sprintf(testFixtureDirFormat, srcDirName)
-	return fmt.Sprintf("%s/"+testFixtureFileFormat, fixtureDirName, partyIndex)
-}
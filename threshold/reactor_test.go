@@ -0,0 +1,336 @@
+package threshold
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	cfg "CipherMachine/config"
+	"CipherMachine/p2p"
+	"CipherMachine/tsslib/ecdsa/keygen"
+	"CipherMachine/tsslib/tss"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// several keygens and signings run at the same time on three connected nodes
+func TestConcurrentSessions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	reactors := makeTestReactors(t, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	keyIDs := []KeyID{"key-0", "key-1"}
+	keys := make([]*KeygenResult, len(keyIDs))
+	var wg sync.WaitGroup
+	for i, keyID := range keyIDs {
+		wg.Add(1)
+		go func(i int, keyID KeyID) {
+			defer wg.Done()
+			keys[i] = reactors[i].Keygen(ctx, KeygenRequest{KeyID: keyID, Threshold: 1})
+		}(i, keyID)
+	}
+	wg.Wait()
+	for i, res := range keys {
+		require.Nil(t, res.Err)
+		waitForShares(t, reactors, keyIDs[i])
+	}
+	// a key id is only generated once
+	res := reactors[2].Keygen(ctx, KeygenRequest{KeyID: keyIDs[0], Threshold: 1})
+	require.NotNil(t, res.Err)
+
+	type signed struct {
+		key int
+		msg *big.Int
+		res *SignResult
+	}
+	signedCh := make(chan signed, len(reactors)*len(keys))
+	for i, r := range reactors {
+		for k, keyID := range keyIDs {
+			wg.Add(1)
+			go func(r *TssReactor, k int, keyID KeyID, msg *big.Int) {
+				defer wg.Done()
+				signedCh <- signed{k, msg, r.Sign(ctx, SignRequest{KeyID: keyID, Msg: msg})}
+			}(r, k, keyID, big.NewInt(int64(10*i+k+1)))
+		}
+	}
+	wg.Wait()
+	close(signedCh)
+	for s := range signedCh {
+		require.Nil(t, s.res.Err)
+		pk := ecdsa.PublicKey{
+			Curve: tss.EC(),
+			X:     keys[s.key].PubKey.X(),
+			Y:     keys[s.key].PubKey.Y(),
+		}
+		r, sig := new(big.Int).SetBytes(s.res.Signature.R), new(big.Int).SetBytes(s.res.Signature.S)
+		require.True(t, ecdsa.Verify(&pk, s.msg.Bytes(), r, sig))
+	}
+
+	// every session frees its entries, joined ones included
+	for _, r := range reactors {
+		require.Eventually(t, func() bool { return r.sessions.size() == 0 }, 30*time.Second, 100*time.Millisecond)
+	}
+
+	// every node lists both keys as used
+	for _, r := range reactors {
+		infos, err := r.ListKeys()
+		require.NoError(t, err)
+		require.Len(t, infos, len(keyIDs))
+		for i, info := range infos {
+			require.Equal(t, keyIDs[i], info.KeyID)
+			require.Equal(t, []byte(keys[i].PubKey.SerializeCompressed()), []byte(info.PubKey))
+			require.False(t, info.Created.IsZero())
+			require.False(t, info.LastUsed.IsZero())
+		}
+	}
+}
+
+// a signing that cannot finish is aborted with the parties it waits for
+func TestSigningTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-node test in short mode")
+	}
+	reactors := makeTestReactors(t, 3)
+	res := reactors[0].Keygen(context.Background(), KeygenRequest{KeyID: "key", Threshold: 1})
+	require.Nil(t, res.Err)
+	waitForShares(t, reactors, "key")
+
+	// node 1 lost its share, it drops every msg of the session
+	reactors[1].tssStore.Delete(reactors[1].newPrefixKey(SaveDataKey, "key"))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	parties := []string{reactors[0].localAddr, reactors[1].localAddr}
+	sres := reactors[0].Sign(ctx, SignRequest{KeyID: "key", Msg: big.NewInt(42), Parties: parties})
+	require.NotNil(t, sres.Err)
+	require.Len(t, sres.Err.Culprits(), 1)
+	require.Equal(t, reactors[1].localAddr, sres.Err.Culprits()[0].Id)
+	require.Equal(t, 0, reactors[0].sessions.size())
+	// the culprit lost trust, the other party did not
+	require.Less(t, reactors[0].TrustScore(reactors[1].localAddr), 100)
+	require.Equal(t, 100, reactors[0].TrustScore(reactors[2].localAddr))
+
+	// a signing canceled by its caller blames no one
+	score := reactors[0].TrustScore(reactors[1].localAddr)
+	cctx, ccancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Second, ccancel)
+	sres = reactors[0].Sign(cctx, SignRequest{KeyID: "key", Msg: big.NewInt(43), Parties: parties})
+	require.NotNil(t, sres.Err)
+	require.Empty(t, sres.Err.Culprits())
+	require.Equal(t, score, reactors[0].TrustScore(reactors[1].localAddr))
+}
+
+// with the refuse policy no session is run with an untrusted peer
+func TestUntrustedPeerRefused(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Tss.UntrustedPeerPolicy = cfg.UntrustedPeerPolicyRefuse
+	db := dbm.NewMemDB()
+	require.NoError(t, InitStore(db, StoreSecret{Passphrase: "test"}))
+	tsr := NewTssReactor(config, db, "a")
+	tsr.peers = []string{"a", "b", "c"}
+	require.NoError(t, tsr.Unlock(StoreSecret{Passphrase: "test"}))
+
+	tsr.recordCulprits(tss.NewError(errors.New("bad proof"), "signing", 1, nil, tss.NewPartyID("b", "b", big.NewInt(2))))
+	require.Less(t, tsr.TrustScore("b"), config.Tss.TrustThreshold)
+	require.Equal(t, 100, tsr.TrustScore("c"))
+
+	res := tsr.Keygen(context.Background(), KeygenRequest{KeyID: "key", Threshold: 1})
+	require.NotNil(t, res.Err)
+	require.Contains(t, res.Err.Error(), "peer b is untrusted")
+}
+
+// a msg is only accepted from the party it claims to be from
+func TestAuthenticateMsg(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Tss.SignMessages = true
+	tsr := NewTssReactor(config, dbm.NewMemDB(), "")
+	key, other := p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}, p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}
+	sign := func(nodeKey p2p.NodeKey, msg *TssMessage) {
+		msg.PubKey = nodeKey.PubKey()
+		bz, err := msg.signBytes()
+		require.NoError(t, err)
+		msg.Signature, err = nodeKey.PrivKey.Sign(bz)
+		require.NoError(t, err)
+	}
+	newMsg := func(from p2p.ID) *TssMessage {
+		return &TssMessage{KeyID: "key", Sid: "sid", PmsgType: SigningMsg, From: tss.NewPartyID(string(from), "", big.NewInt(1))}
+	}
+
+	msg := newMsg(key.ID())
+	sign(key, msg)
+	require.NoError(t, msg.VerifySignature())
+	require.NoError(t, tsr.authenticateMsg(key.ID(), msg))
+
+	// another peer relays the msg
+	require.Error(t, tsr.authenticateMsg(other.ID(), msg))
+
+	// the msg is changed after it was signed
+	msg.Sid = "other-sid"
+	require.Error(t, msg.VerifySignature())
+	require.Error(t, tsr.authenticateMsg(key.ID(), msg))
+
+	// a peer claims to be another party
+	msg = newMsg(key.ID())
+	sign(other, msg)
+	require.Error(t, msg.VerifySignature())
+	require.Error(t, tsr.authenticateMsg(other.ID(), msg))
+
+	// a msg of no party is refused, resharing announcements aside
+	msg = newMsg(key.ID())
+	msg.From = nil
+	require.Equal(t, errNoSender, tsr.authenticateMsg(key.ID(), msg))
+	msg.PmsgType = ResharingMsg
+	msg.Pmsg = []byte{1}
+	require.Equal(t, errNoSender, tsr.authenticateMsg(key.ID(), msg))
+	msg.Pmsg = nil
+	tsr.tssConfig.SignMessages = false
+	require.NoError(t, tsr.authenticateMsg(key.ID(), msg))
+	tsr.tssConfig.SignMessages = true
+
+	// unsigned msgs are refused
+	msg = newMsg(key.ID())
+	require.Equal(t, errUnsignedMsg, tsr.authenticateMsg(key.ID(), msg))
+	tsr.tssConfig.SignMessages = false
+	require.NoError(t, tsr.authenticateMsg(key.ID(), msg))
+	require.Error(t, tsr.authenticateMsg(other.ID(), msg))
+}
+
+// makeTestReactors starts n connected nodes sharing the persistent peers, the
+// keygen pre-params are read from the test fixtures and msgs are signed.
+func makeTestReactors(t *testing.T, n int) []*TssReactor {
+	config := cfg.TestConfig()
+	config.Tss.SignMessages = true
+	reactors := make([]*TssReactor, n)
+	switches := make([]*p2p.Switch, n)
+	var peers []string
+	for i := range switches {
+		switches[i] = p2p.MakeSwitch(config.P2P, i, p2p.TEST_HOST, "123.123.123", func(i int, sw *p2p.Switch) *p2p.Switch {
+			db := dbm.NewMemDB()
+			require.NoError(t, InitStore(db, StoreSecret{Passphrase: "test"}))
+			reactors[i] = NewTssReactor(config, db, "")
+			reactors[i].SetLogger(log.TestingLogger().With("node", i))
+			preParams := loadTestPreParams(t, i)
+			reactors[i].preParams = func() (*keygen.LocalPreParams, error) { return preParams, nil }
+			require.NoError(t, reactors[i].Unlock(StoreSecret{Passphrase: "test"}))
+			sw.AddReactor("tss", reactors[i])
+			return sw
+		})
+		peers = append(peers, string(switches[i].NodeInfo().ID()))
+	}
+	// the node ids are known once the switches exist, set them before any
+	// msg can arrive
+	for i, r := range reactors {
+		r.localAddr = peers[i]
+		r.peers = peers
+	}
+	require.NoError(t, p2p.StartSwitches(switches))
+	t.Cleanup(func() {
+		for _, sw := range switches {
+			sw.Stop()
+		}
+	})
+	for i := range switches {
+		for j := i + 1; j < n; j++ {
+			require.NoError(t, switches[i].DialPeerWithAddress(switches[j].NetAddress()))
+		}
+	}
+	for _, sw := range switches {
+		require.Eventually(t, func() bool { return sw.Peers().Size() == n-1 }, 10*time.Second, 10*time.Millisecond)
+	}
+	return reactors
+}
+
+// keygen returns once the local party is done, the other parties store their
+// shares a little later
+func waitForShares(t *testing.T, reactors []*TssReactor, keyID KeyID) {
+	for _, r := range reactors {
+		require.Eventually(t, func() bool {
+			return r.tssStore.Has(r.newPrefixKey(SaveDataKey, keyID))
+		}, 30*time.Second, 100*time.Millisecond)
+	}
+}
+
+func loadTestPreParams(t *testing.T, i int) *keygen.LocalPreParams {
+	_, file, _, _ := runtime.Caller(0)
+	bz, err := ioutil.ReadFile(filepath.Join(filepath.Dir(file), "test", fmt.Sprintf("preparams_%d.json", i)))
+	require.NoError(t, err)
+	preParams := new(keygen.LocalPreParams)
+	require.NoError(t, json.Unmarshal(bz, preParams))
+	return preParams
+}
+
+// a resharing is only joined if it is announced by a member of a committee and
+// matches the share the node holds, and if the new share would be usable
+func TestResharingAnnouncement(t *testing.T) {
+	db := dbm.NewMemDB()
+	require.NoError(t, InitStore(db, StoreSecret{Passphrase: "test"}))
+	tsr := NewTssReactor(cfg.TestConfig(), db, "aa")
+	tsr.peers = []string{"aa", "bb", "cc"}
+	require.NoError(t, tsr.Unlock(StoreSecret{Passphrase: "test"}))
+	saveData := loadTestSaveData(t, 0)
+	require.NoError(t, tsr.setSaveData("key", saveData))
+	newMsg := func() TssMessage {
+		return TssMessage{
+			KeyID:        "key",
+			Sid:          newSessionID(),
+			Threshold:    1,
+			PmsgType:     ResharingMsg,
+			OldParties:   saveData.PartySaveData.SortedPartyIDs,
+			NewParties:   generateResharingPIDs([]string{"aa", "cc"}),
+			NewThreshold: 1,
+		}
+	}
+
+	// a peer in neither committee
+	tsr.receiveResharing(testPeer{id: "dd"}, newMsg())
+	require.Equal(t, 0, tsr.sessions.size())
+
+	// a member claims another threshold than the one of the share
+	msg := newMsg()
+	msg.Threshold = 2
+	tsr.receiveResharing(testPeer{id: "bb"}, msg)
+	require.Equal(t, 0, tsr.sessions.size())
+	_, err := tsr.resharing(&msg)
+	require.EqualError(t, err, "resharing msg has threshold 2, key key has 1")
+
+	// or another old committee
+	msg = newMsg()
+	msg.OldParties = generatePIDs([]string{"aa", "bb"})
+	_, err = tsr.resharing(&msg)
+	require.EqualError(t, err, "resharing msg has another old committee than key key")
+
+	// a new member that is not a persistent peer
+	msg = newMsg()
+	msg.NewParties = generateResharingPIDs([]string{"aa", "dd"})
+	_, err = tsr.resharing(&msg)
+	require.EqualError(t, err, "peer dd of the new committee is not in the persistent peers")
+
+	// a new member that holds another key under the same id
+	msg = newMsg()
+	msg.OldParties = generatePIDs([]string{"bb", "cc"})
+	_, err = tsr.resharing(&msg)
+	require.EqualError(t, err, "key key exists")
+	_, err = tsr.getSaveData("key")
+	require.NoError(t, err)
+}
+
+// testPeer is a peer of which only the id is known.
+type testPeer struct {
+	p2p.Peer
+	id p2p.ID
+}
+
+func (p testPeer) ID() p2p.ID { return p.id }
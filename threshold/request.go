@@ -0,0 +1,101 @@
+package threshold
+
+import (
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/tss"
+	"math/big"
+)
+
+// KeygenRequest asks the persistent peers to generate a new key of KeyType,
+// an ECDSA key if it is empty, on Curve, the default curve of the key type if
+// it is empty. ECDSA keys may be on tss.Secp256k1 or tss.P256. A zero
+// Threshold is the threshold of the [tss] config.
+type KeygenRequest struct {
+	KeyID     KeyID
+	KeyType   KeyType
+	Curve     tss.CurveName
+	Threshold int
+}
+
+// KeygenResult carries the public key of a finished keygen, or the error
+// it failed with.
+type KeygenResult struct {
+	KeyID   KeyID
+	KeyType KeyType
+	Curve   tss.CurveName
+	PubKey  *crypto.ECPoint
+	Err     *tss.Error
+}
+
+// SignRequest asks the holders of a key to sign Msg, a digest of at most 32
+// bytes. Data replaces Msg if it is set, an EdDSA or Schnorr key signs it as is,
+// an ECDSA key takes it for the digest. HashAlg tells how Msg was hashed and is
+// recorded in the result. Parties optionally names the signing quorum, at
+// least threshold+1 node ids including the local node. Path optionally names
+// the non-hardened BIP32 child of the key that signs, ECDSA keys only.
+// Taproot optionally makes a Schnorr key sign for the key path spend of a
+// taproot output.
+type SignRequest struct {
+	KeyID   KeyID
+	Msg     *big.Int
+	Data    []byte
+	HashAlg HashAlg
+	Parties []string
+	Path    []uint32
+	Taproot *TaprootTweak
+}
+
+// TaprootTweak names the BIP341 taproot output of an internal Schnorr key,
+// its output key is the internal key tweaked by the MerkleRoot of the script
+// tree. An empty MerkleRoot is an output without scripts.
+type TaprootTweak struct {
+	MerkleRoot []byte
+}
+
+// SignResult carries the signature of a finished signing, or the error it
+// failed with. Signature.M is the 32 byte digest that was signed, hashed from
+// the message with HashAlg, or the message an EdDSA or Schnorr key signed.
+type SignResult struct {
+	KeyID     KeyID
+	Signature *common.SignatureData
+	HashAlg   HashAlg
+	Err       *tss.Error
+}
+
+// BatchSignRequest asks the holders of an ECDSA key to sign Digests, digests
+// of at most 32 bytes, in a single session. HashAlg, Parties and Path are
+// those of SignRequest. The digests are signed with presignatures of the
+// local node if it has enough of them, else the presignatures are made in the
+// same session.
+type BatchSignRequest struct {
+	KeyID   KeyID
+	Digests [][]byte
+	HashAlg HashAlg
+	Parties []string
+	Path    []uint32
+}
+
+// PresignRequest asks the holders of an ECDSA key to make Count presignatures
+// for the signing quorum Parties, picked from the connected peers if empty.
+// The presignatures belong to the local node, only its signings use them.
+type PresignRequest struct {
+	KeyID   KeyID
+	Parties []string
+	Count   int
+}
+
+// PresignResult carries the number of presignatures made, or the error the
+// presigning failed with.
+type PresignResult struct {
+	KeyID KeyID
+	Count int
+	Err   *tss.Error
+}
+
+// ResharingResult tells whether a resharing finished, or the error it failed
+// with.
+type ResharingResult struct {
+	KeyID KeyID
+	Err   *tss.Error
+}
@@ -0,0 +1,179 @@
+package threshold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"CipherMachine/tsslib/tss"
+)
+
+const (
+	// bounds of the msgs held for sessions that are not added yet
+	maxHeldSessions = 64
+	maxHeldMsgs     = 16
+)
+
+var (
+	errSessionExists = errors.New("session exists")
+	errSessionEnded  = errors.New("session has ended")
+)
+
+// session is a keygen, signing or resharing run of this node.
+type session struct {
+	keyID KeyID
+	//keygen and resharing write the share of the key, only one of them may
+	//run per key at a time
+	exclusive bool
+	//the parties msgs of the session may come from
+	pIDs   tss.SortedPartyIDs
+	inbox  *sessionInbox
+	cancel context.CancelFunc
+}
+
+// sessionRegistry tracks the sessions of a reactor. A session is added once
+// its parties are set up and is owned by its session routine from then on,
+// only that routine removes it again by calling end. Ended sessions are
+// remembered for timeout, so late msgs of a session do not start it again.
+type sessionRegistry struct {
+	mtx      sync.Mutex
+	timeout  time.Duration
+	sessions map[SessionID]*session
+	ended    map[SessionID]time.Time
+	busyKeys map[KeyID]SessionID
+	//msgs of sessions this node may only join on a msg of a given party,
+	//held until that msg adds the session
+	held map[SessionID]*heldMsgs
+}
+
+// heldMsgs are the msgs held for a session that is not added yet.
+type heldMsgs struct {
+	msgs  []inboxMsg
+	since time.Time
+}
+
+func newSessionRegistry(timeout time.Duration) *sessionRegistry {
+	return &sessionRegistry{
+		timeout:  timeout,
+		sessions: make(map[SessionID]*session),
+		ended:    make(map[SessionID]time.Time),
+		busyKeys: make(map[KeyID]SessionID),
+		held:     make(map[SessionID]*heldMsgs),
+	}
+}
+
+func (r *sessionRegistry) add(sid SessionID, s *session) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.sessions[sid]; ok {
+		return errSessionExists
+	}
+	if _, ok := r.ended[sid]; ok {
+		return errSessionEnded
+	}
+	if s.exclusive {
+		if other, ok := r.busyKeys[s.keyID]; ok {
+			return fmt.Errorf("key %s is busy with session %s", s.keyID, other)
+		}
+		r.busyKeys[s.keyID] = sid
+	}
+	r.sessions[sid] = s
+	if h, ok := r.held[sid]; ok {
+		delete(r.held, sid)
+		for _, msg := range h.msgs {
+			if s.isParty(msg.pMsg.GetFrom()) {
+				s.inbox.push(msg)
+			}
+		}
+	}
+	return nil
+}
+
+// hold keeps msg of session sid until the session is added, it reports false
+// if the session is added or ended already. Msgs over the bounds are dropped.
+func (r *sessionRegistry) hold(sid SessionID, msg inboxMsg) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.sessions[sid]; ok {
+		return false
+	}
+	if _, ok := r.ended[sid]; ok {
+		return false
+	}
+	now := time.Now()
+	for id, h := range r.held {
+		if now.Sub(h.since) > r.timeout {
+			delete(r.held, id)
+		}
+	}
+	h, ok := r.held[sid]
+	if !ok {
+		if len(r.held) >= maxHeldSessions {
+			return true
+		}
+		h = &heldMsgs{since: now}
+		r.held[sid] = h
+	}
+	if len(h.msgs) < maxHeldMsgs {
+		h.msgs = append(h.msgs, msg)
+	}
+	return true
+}
+
+func (r *sessionRegistry) get(sid SessionID) *session {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.sessions[sid]
+}
+
+func (r *sessionRegistry) hasEnded(sid SessionID) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	_, ok := r.ended[sid]
+	return ok
+}
+
+// keyBusy reports whether a keygen or resharing of keyID is running.
+func (r *sessionRegistry) keyBusy(keyID KeyID) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	_, ok := r.busyKeys[keyID]
+	return ok
+}
+
+func (r *sessionRegistry) size() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return len(r.sessions)
+}
+
+// end aborts the parties of session sid and frees its entries.
+func (r *sessionRegistry) end(sid SessionID) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	s, ok := r.sessions[sid]
+	if !ok {
+		return
+	}
+	s.cancel()
+	delete(r.sessions, sid)
+	if r.busyKeys[s.keyID] == sid {
+		delete(r.busyKeys, s.keyID)
+	}
+
+	now := time.Now()
+	for id, ended := range r.ended {
+		if now.Sub(ended) > r.timeout {
+			delete(r.ended, id)
+		}
+	}
+	r.ended[sid] = now
+}
+
+// SessionCount returns the number of sessions running on the node, joined
+// ones included.
+func (tsr *TssReactor) SessionCount() int {
+	return tsr.sessions.size()
+}
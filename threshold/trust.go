@@ -0,0 +1,80 @@
+package threshold
+
+import (
+	"fmt"
+
+	cfg "CipherMachine/config"
+	"CipherMachine/p2p"
+	"CipherMachine/tsslib/tss"
+)
+
+// the trust of the peers is kept in the trust metric store, every culprit of a
+// failed session is a bad event of its peer and every finished session a good
+// event of the other parties.
+
+// recordCulprits lowers the trust of the peers err blames.
+func (tsr *TssReactor) recordCulprits(err *tss.Error) {
+	if err == nil {
+		return
+	}
+	for _, culprit := range err.Culprits() {
+		if culprit == nil {
+			continue
+		}
+		tsr.recordBadPeer(culprit.Id, fmt.Sprintf("culprit of %s round %d", err.Task(), err.Round()))
+	}
+}
+
+// recordBadPeer lowers the trust of peer id, with the disconnect policy a
+// peer that becomes untrusted is dropped.
+func (tsr *TssReactor) recordBadPeer(id string, reason string) {
+	if id == tsr.localAddr {
+		return
+	}
+	tm := tsr.trustStore.GetPeerTrustMetric(id)
+	tm.BadEvents(1)
+	score := tm.TrustScore()
+	tsr.Logger.Info("peer lost trust", "peer", id, "reason", reason, "score", score)
+
+	if tsr.tssConfig.UntrustedPeerPolicy != cfg.UntrustedPeerPolicyDisconnect || score >= tsr.tssConfig.TrustThreshold {
+		return
+	}
+	if tsr.Switch == nil {
+		return
+	}
+	if peer := tsr.Switch.Peers().Get(p2p.ID(id)); peer != nil {
+		tsr.Switch.StopPeerForError(peer, fmt.Errorf("untrusted tss peer, trust score %d", score))
+	}
+}
+
+// recordGoodParties raises the trust of the peers of a finished session.
+func (tsr *TssReactor) recordGoodParties(peers []string) {
+	for _, id := range peers {
+		if id == tsr.localAddr {
+			continue
+		}
+		tsr.trustStore.GetPeerTrustMetric(id).GoodEvents(1)
+	}
+}
+
+// checkTrusted returns an error if the policy refuses sessions with one of
+// peers.
+func (tsr *TssReactor) checkTrusted(peers []string) error {
+	if tsr.tssConfig.UntrustedPeerPolicy == cfg.UntrustedPeerPolicyNone {
+		return nil
+	}
+	for _, id := range peers {
+		if id == tsr.localAddr {
+			continue
+		}
+		if score := tsr.trustStore.GetPeerTrustMetric(id).TrustScore(); score < tsr.tssConfig.TrustThreshold {
+			return fmt.Errorf("peer %s is untrusted, trust score %d", id, score)
+		}
+	}
+	return nil
+}
+
+// TrustScore returns the trust score (0-100) of peer id.
+func (tsr *TssReactor) TrustScore(id string) int {
+	return tsr.trustStore.GetPeerTrustMetric(id).TrustScore()
+}
@@ -1,9 +1,15 @@
 package threshold
 
 import (
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/tss"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
-	"CipherMachine/tsslib/tss"
+	"math/big"
 	"strings"
 )
 
@@ -16,9 +22,17 @@ func splitPeerFromPersistentPeer(persistentPeer string) (peersIDs []string) {
 	return
 }
 
-func generatePIDs(peersIDs []string) tss.SortedPartyIDs{
-	ids := make(tss.UnSortedPartyIDs, 0, )
-	for i := range peersIDs{
+func newSessionID() SessionID {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return SessionID(hex.EncodeToString(b))
+}
+
+func generatePIDs(peersIDs []string) tss.SortedPartyIDs {
+	ids := make(tss.UnSortedPartyIDs, 0)
+	for i := range peersIDs {
 		key, err := hex.DecodeString(peersIDs[i])
 		if err != nil {
 			panic(err)
@@ -33,4 +47,115 @@ func generatePIDs(peersIDs []string) tss.SortedPartyIDs{
 		})
 	}
 	return tss.SortPartyIDs(ids)
-}
\ No newline at end of file
+}
+
+// generateResharingPIDs builds the party ids of a new committee. The keys are
+// salted with a random nonce, so a node sitting in both committees runs its
+// old and new party under different keys.
+func generateResharingPIDs(peersIDs []string) tss.SortedPartyIDs {
+	nonce := common.MustGetRandomInt(256).Bytes()
+	ids := make(tss.UnSortedPartyIDs, 0, len(peersIDs))
+	for i := range peersIDs {
+		id, err := hex.DecodeString(peersIDs[i])
+		if err != nil {
+			panic(err)
+		}
+		key := sha256.Sum256(append(id, nonce...))
+		ids = append(ids, &tss.PartyID{
+			MessageWrapper_PartyID: &tss.MessageWrapper_PartyID{
+				Id:      peersIDs[i],
+				Moniker: fmt.Sprintf("tss-peer[%d]", i+1),
+				// keys are matched against the saved Ks as big ints, drop leading zeros
+				Key: new(big.Int).SetBytes(key[:]).Bytes(),
+			},
+			Index: i,
+		})
+	}
+	return tss.SortPartyIDs(ids)
+}
+
+func findPartyIndex(pIDs tss.SortedPartyIDs, peerID string) int {
+	for i := range pIDs {
+		if pIDs[i].Id == peerID {
+			return i
+		}
+	}
+	return -1
+}
+
+func peersOf(pIDs tss.SortedPartyIDs) (peersIDs []string) {
+	for _, pID := range pIDs {
+		peersIDs = append(peersIDs, pID.Id)
+	}
+	return
+}
+
+func containsPeer(peersIDs []string, peerID string) bool {
+	for _, id := range peersIDs {
+		if id == peerID {
+			return true
+		}
+	}
+	return false
+}
+
+func unionPeers(a, b []string) []string {
+	union := append([]string{}, a...)
+	for _, id := range b {
+		if !containsPeer(union, id) {
+			union = append(union, id)
+		}
+	}
+	return union
+}
+
+// samePartyIDs reports whether a and b are the same parties, with the same
+// indexes and keys, in the same order.
+func samePartyIDs(a, b tss.SortedPartyIDs) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Id != b[i].Id || a[i].Index != b[i].Index || !bytes.Equal(a[i].Key, b[i].Key) {
+			return false
+		}
+	}
+	return true
+}
+
+// subsetPIDs copies the party ids of peersIDs out of pIDs and re-indexes them,
+// so the saved party ids are left untouched.
+func subsetPIDs(pIDs tss.SortedPartyIDs, peersIDs []string) tss.SortedPartyIDs {
+	ids := make(tss.UnSortedPartyIDs, 0, len(peersIDs))
+	for _, pID := range pIDs {
+		if !containsPeer(peersIDs, pID.Id) {
+			continue
+		}
+		ids = append(ids, &tss.PartyID{
+			MessageWrapper_PartyID: &tss.MessageWrapper_PartyID{
+				Id:      pID.Id,
+				Moniker: pID.Moniker,
+				Key:     pID.Key,
+			},
+			Index: pID.Index,
+		})
+	}
+	return tss.SortPartyIDs(ids)
+}
+
+// waitingForPeers lists the remote parties P still waits for when ctx is
+// done, a local party that has not finished its round is slow, not a culprit.
+// Only a session that timed out blames them, a session canceled by its caller
+// blames no one.
+func (tsr *TssReactor) waitingForPeers(ctx context.Context, P tss.Party) []*tss.PartyID {
+	if ctx.Err() != context.DeadlineExceeded {
+		return nil
+	}
+	var culprits []*tss.PartyID
+	for _, pID := range P.WaitingFor() {
+		if pID.Id != tsr.localAddr {
+			culprits = append(culprits, pID)
+		}
+	}
+	return culprits
+}
@@ -3,6 +3,8 @@ package threshold
 import (
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestSplitPeer(t *testing.T) {
@@ -14,4 +16,12 @@ func TestSplit(t *testing.T) {
 	s := "ws-ib6tx0zzublt9-79c9964954-ps422"
 	aa := s[:16]
 	fmt.Println(aa)
-}
\ No newline at end of file
+}
+
+func TestSamePartyIDs(t *testing.T) {
+	pIDs := generatePIDs([]string{"aa", "bb", "cc"})
+	require.True(t, samePartyIDs(pIDs, generatePIDs([]string{"aa", "bb", "cc"})))
+	require.False(t, samePartyIDs(pIDs, generatePIDs([]string{"aa", "bb"})))
+	require.False(t, samePartyIDs(pIDs, generatePIDs([]string{"aa", "bb", "dd"})))
+	require.False(t, samePartyIDs(pIDs, generateResharingPIDs([]string{"aa", "bb", "cc"})))
+}
@@ -45,6 +45,11 @@ func NewECPointNoCurveCheck(curve elliptic.Curve, X, Y *big.Int) *ECPoint {
 	return &ECPoint{curve, [2]*big.Int{X, Y}}
 }
 
+// Curve returns the curve of the point.
+func (p *ECPoint) Curve() elliptic.Curve {
+	return p.curve
+}
+
 func (p *ECPoint) X() *big.Int {
 	return new(big.Int).Set(p.coords[0])
 }
@@ -84,6 +89,30 @@ func (p *ECPoint) ValidateBasic() bool {
 	return p != nil && p.coords[0] != nil && p.coords[1] != nil && p.IsOnCurve()
 }
 
+// SerializeCompressed returns the SEC 1 compressed encoding of the point, the
+// parity of Y as 0x02 or 0x03 followed by X.
+func (p *ECPoint) SerializeCompressed() []byte {
+	byteLen := (p.curve.Params().BitSize + 7) / 8
+	out := make([]byte, 1+byteLen)
+	out[0] = 0x02 | byte(p.coords[1].Bit(0))
+	xBz := p.coords[0].Bytes()
+	copy(out[1+byteLen-len(xBz):], xBz)
+	return out
+}
+
+// SerializeXOnly returns the 32 byte X of the point, the BIP340 encoding of the point with the same X and an even Y.
+func (p *ECPoint) SerializeXOnly() []byte {
+	bz := make([]byte, 32)
+	p.X().FillBytes(bz)
+	return bz
+}
+
+// SerializeEd25519 returns the RFC 8032 encoding of a point of the Ed25519 curve,
+// Y in little-endian with the sign of X in the top bit.
+func (p *ECPoint) SerializeEd25519() []byte {
+	return edwards.NewPublicKey(p.coords[0], p.coords[1]).Serialize()
+}
+
 func (p *ECPoint) EightInvEight() *ECPoint {
 	return p.ScalarMult(eight).ScalarMult(eightInv)
 }
@@ -145,7 +174,13 @@ func UnFlattenECPoints(curve elliptic.Curve, in []*big.Int, noCurveCheck ...bool
 // ----- //
 // Gob helpers for if you choose to encode messages with Gob.
 
+// GobEncode writes the name of the curve before the coordinates, like the json
+// of a point
 func (p *ECPoint) GobEncode() ([]byte, error) {
+	ecName, ok := tss.GetCurveName(p.curve)
+	if !ok {
+		return nil, fmt.Errorf("ECPoint.GobEncode: the curve %s is not registered, call tss.RegisterCurve first", p.curve.Params().Name)
+	}
 	buf := &bytes.Buffer{}
 	x, err := p.coords[0].GobEncode()
 	if err != nil {
@@ -156,6 +191,11 @@ func (p *ECPoint) GobEncode() ([]byte, error) {
 		return nil, err
 	}
 
+	err = binary.Write(buf, binary.LittleEndian, uint32(len(ecName)))
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteString(string(ecName))
 	err = binary.Write(buf, binary.LittleEndian, uint32(len(x)))
 	if err != nil {
 		return nil, err
@@ -170,14 +210,27 @@ func (p *ECPoint) GobEncode() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// GobDecode reads a point on its named curve
 func (p *ECPoint) GobDecode(buf []byte) error {
 	reader := bytes.NewReader(buf)
 	var length uint32
 	if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
 		return err
 	}
+	name := make([]byte, length)
+	n, err := reader.Read(name)
+	if n != int(length) || err != nil {
+		return fmt.Errorf("gob decode failed: %v", err)
+	}
+	ec, ok := tss.GetCurveByName(tss.CurveName(name))
+	if !ok {
+		return fmt.Errorf("ECPoint.GobDecode: unknown curve %s", name)
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+		return err
+	}
 	x := make([]byte, length)
-	n, err := reader.Read(x)
+	n, err = reader.Read(x)
 	if n != int(length) || err != nil {
 		return fmt.Errorf("gob decode failed: %v", err)
 	}
@@ -198,33 +251,50 @@ func (p *ECPoint) GobDecode(buf []byte) error {
 	if err := Y.GobDecode(y); err != nil {
 		return err
 	}
-	p.curve = tss.EC()
+	p.curve = ec
 	p.coords = [2]*big.Int{X, Y}
 	if !p.IsOnCurve() {
-		return errors.New("ECPoint.UnmarshalJSON: the point is not on the elliptic curve")
+		return errors.New("ECPoint.GobDecode: the point is not on the elliptic curve")
 	}
 	return nil
 }
 
 // ----- //
 
-// crypto.ECPoint is not inherently json marshal-able
+// crypto.ECPoint is not inherently json marshal-able, its json names the curve
+// registered for it
 func (p *ECPoint) MarshalJSON() ([]byte, error) {
+	ecName, ok := tss.GetCurveName(p.curve)
+	if !ok {
+		return nil, fmt.Errorf("ECPoint.MarshalJSON: the curve %s is not registered, call tss.RegisterCurve first", p.curve.Params().Name)
+	}
 	return json.Marshal(&struct {
+		Curve  string
 		Coords [2]*big.Int
 	}{
+		Curve:  string(ecName),
 		Coords: p.coords,
 	})
 }
 
+// UnmarshalJSON reads a point on its named curve, a point saved without one
+// is on the default curve
 func (p *ECPoint) UnmarshalJSON(payload []byte) error {
 	aux := &struct {
+		Curve  string
 		Coords [2]*big.Int
 	}{}
 	if err := json.Unmarshal(payload, &aux); err != nil {
 		return err
 	}
 	p.curve = tss.EC()
+	if aux.Curve != "" {
+		ec, ok := tss.GetCurveByName(tss.CurveName(aux.Curve))
+		if !ok {
+			return fmt.Errorf("ECPoint.UnmarshalJSON: unknown curve %s", aux.Curve)
+		}
+		p.curve = ec
+	}
 	p.coords = [2]*big.Int{aux.Coords[0], aux.Coords[1]}
 	if !p.IsOnCurve() {
 		return errors.New("ECPoint.UnmarshalJSON: the point is not on the elliptic curve")
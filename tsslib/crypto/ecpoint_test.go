@@ -7,11 +7,20 @@
 package crypto_test
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha512"
+	"encoding/gob"
+	"encoding/json"
 	"math/big"
 	"reflect"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
+
 	. "CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/common"
 	"CipherMachine/tsslib/tss"
 )
 
@@ -113,3 +122,90 @@ func TestUnFlattenECPoints(t *testing.T) {
 		})
 	}
 }
+
+func TestSerializeCompressed(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		p := ScalarBaseMult(tss.EC(), common.GetRandomPositiveInt(tss.EC().Params().N))
+		want := (&btcec.PublicKey{Curve: tss.EC(), X: p.X(), Y: p.Y()}).SerializeCompressed()
+		if got := p.SerializeCompressed(); !reflect.DeepEqual(got, want) {
+			t.Errorf("SerializeCompressed() = %x, want %x", got, want)
+		}
+	}
+}
+
+func TestSerializeEd25519(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		seed := common.GetRandomPositiveInt(tss.Edwards().Params().N).Bytes()
+		seed = append(make([]byte, ed25519.SeedSize-len(seed)), seed...)
+		want := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+
+		// the secret scalar of RFC 8032, clamped and little-endian
+		h := sha512.Sum512(seed)
+		h[0] &= 248
+		h[31] &= 127
+		h[31] |= 64
+		for l, r := 0, 31; l < r; l, r = l+1, r-1 {
+			h[l], h[r] = h[r], h[l]
+		}
+		p := ScalarBaseMult(tss.Edwards(), new(big.Int).SetBytes(h[:32]))
+		if got := p.SerializeEd25519(); !reflect.DeepEqual(got, []byte(want)) {
+			t.Errorf("SerializeEd25519() = %x, want %x", got, want)
+		}
+	}
+}
+
+func TestECPointJSON(t *testing.T) {
+	for _, ec := range []elliptic.Curve{tss.S256(), elliptic.P256(), tss.Edwards()} {
+		p := ScalarBaseMult(ec, common.GetRandomPositiveInt(ec.Params().N))
+		bz, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("MarshalJSON() error = %v", err)
+		}
+		got := new(ECPoint)
+		if err := json.Unmarshal(bz, got); err != nil {
+			t.Fatalf("UnmarshalJSON() error = %v", err)
+		}
+		if got.Curve() != ec || !got.Equals(p) {
+			t.Errorf("UnmarshalJSON() = %s, want the point on %s", bz, ec.Params().Name)
+		}
+	}
+
+	// a point saved without its curve is on the default curve
+	p := ScalarBaseMult(tss.EC(), big.NewInt(7))
+	bz, _ := json.Marshal(&struct{ Coords [2]*big.Int }{[2]*big.Int{p.X(), p.Y()}})
+	got := new(ECPoint)
+	if err := json.Unmarshal(bz, got); err != nil || got.Curve() != tss.EC() || !got.Equals(p) {
+		t.Errorf("UnmarshalJSON() of a point without a curve = %v, %v", got, err)
+	}
+	bz = []byte(`{"Curve":"unknown","Coords":[1,2]}`)
+	if err := json.Unmarshal(bz, new(ECPoint)); err == nil {
+		t.Errorf("UnmarshalJSON() of a point on an unknown curve must fail")
+	}
+}
+
+func TestECPointGob(t *testing.T) {
+	for _, ec := range []elliptic.Curve{tss.S256(), elliptic.P256(), tss.Edwards()} {
+		p := ScalarBaseMult(ec, common.GetRandomPositiveInt(ec.Params().N))
+		buf := &bytes.Buffer{}
+		if err := gob.NewEncoder(buf).Encode(p); err != nil {
+			t.Fatalf("GobEncode() error = %v", err)
+		}
+		got := new(ECPoint)
+		if err := gob.NewDecoder(buf).Decode(got); err != nil {
+			t.Fatalf("GobDecode() error = %v", err)
+		}
+		if got.Curve() != ec || !got.Equals(p) {
+			t.Errorf("GobDecode() = %v, want the point on %s", got, ec.Params().Name)
+		}
+	}
+
+	bz, err := ScalarBaseMult(tss.EC(), big.NewInt(7)).GobEncode()
+	if err != nil {
+		t.Fatalf("GobEncode() error = %v", err)
+	}
+	// the name of the curve comes first, after its length
+	copy(bz[4:], "unknown")
+	if err := new(ECPoint).GobDecode(bz); err == nil {
+		t.Errorf("GobDecode() of a point on an unknown curve must fail")
+	}
+}
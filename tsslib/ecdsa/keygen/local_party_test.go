@@ -41,7 +41,7 @@ func TestStartRound1Paillier(t *testing.T) {
 	pIDs := tss.GenerateTestPartyIDs(1)
 	p2pCtx := tss.NewPeerContext(pIDs)
 	threshold := 1
-	params := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), threshold)
+	params := tss.NewParameters(tss.EC(), p2pCtx, pIDs[0], len(pIDs), threshold)
 
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
@@ -81,7 +81,7 @@ func TestFinishAndSaveH1H2(t *testing.T) {
 	pIDs := tss.GenerateTestPartyIDs(1)
 	p2pCtx := tss.NewPeerContext(pIDs)
 	threshold := 1
-	params := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), threshold)
+	params := tss.NewParameters(tss.EC(), p2pCtx, pIDs[0], len(pIDs), threshold)
 
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
@@ -129,7 +129,7 @@ func TestBadMessageCulprits(t *testing.T) {
 
 	pIDs := tss.GenerateTestPartyIDs(2)
 	p2pCtx := tss.NewPeerContext(pIDs)
-	params := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), 1)
+	params := tss.NewParameters(tss.EC(), p2pCtx, pIDs[0], len(pIDs), 1)
 
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
@@ -188,7 +188,7 @@ func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	// init the parties
 	for i := 0; i < len(pIDs); i++ {
 		var P *LocalParty
-		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		params := tss.NewParameters(tss.EC(), p2pCtx, pIDs[i], len(pIDs), threshold)
 		if i < len(fixtures) {
 			P = NewLocalParty(params, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty)
 		} else {
@@ -258,7 +258,7 @@ keygen:
 				//		}
 				//		pShares = append(pShares, shareStruct)
 				//	}
-				//	uj, err := pShares[:threshold+1].ReConstruct()
+				//	uj, err := pShares[:threshold+1].ReConstruct(tss.EC())
 				//	assert.NoError(t, err, "vss.ReConstruct should not throw error")
 				//
 				//	// uG test: u*G[j] == V[0]
@@ -276,7 +276,7 @@ keygen:
 				//	{
 				//		badShares := pShares[:threshold]
 				//		badShares[len(badShares)-1].Share.Set(big.NewInt(0))
-				//		uj, err := pShares[:threshold].ReConstruct()
+				//		uj, err := pShares[:threshold].ReConstruct(tss.EC())
 				//		assert.NoError(t, err)
 				//		assert.NotEqual(t, parties[j].temp.ui, uj)
 				//		BigXjX, BigXjY := tss.EC().ScalarBaseMult(uj.Bytes())
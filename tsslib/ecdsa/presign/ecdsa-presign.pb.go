@@ -0,0 +1,358 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: protob/ecdsa-presign.proto
+
+package presign
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Represents a P2P message sent to each party during Round 1 of the ECDSA TSS presigning protocol,
+// one entry of each field per presignature.
+type PresignRound1Message1 struct {
+	C                    [][]byte `protobuf:"bytes,1,rep,name=c,proto3" json:"c,omitempty"`
+	RangeProofAlice      [][]byte `protobuf:"bytes,2,rep,name=range_proof_alice,json=rangeProofAlice,proto3" json:"range_proof_alice,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PresignRound1Message1) Reset()         { *m = PresignRound1Message1{} }
+func (m *PresignRound1Message1) String() string { return proto.CompactTextString(m) }
+func (*PresignRound1Message1) ProtoMessage()    {}
+func (*PresignRound1Message1) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9fc566df8122cad, []int{0}
+}
+
+func (m *PresignRound1Message1) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PresignRound1Message1.Unmarshal(m, b)
+}
+func (m *PresignRound1Message1) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PresignRound1Message1.Marshal(b, m, deterministic)
+}
+func (m *PresignRound1Message1) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresignRound1Message1.Merge(m, src)
+}
+func (m *PresignRound1Message1) XXX_Size() int {
+	return xxx_messageInfo_PresignRound1Message1.Size(m)
+}
+func (m *PresignRound1Message1) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresignRound1Message1.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresignRound1Message1 proto.InternalMessageInfo
+
+func (m *PresignRound1Message1) GetC() [][]byte {
+	if m != nil {
+		return m.C
+	}
+	return nil
+}
+
+func (m *PresignRound1Message1) GetRangeProofAlice() [][]byte {
+	if m != nil {
+		return m.RangeProofAlice
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 1 of the ECDSA TSS presigning protocol.
+type PresignRound1Message2 struct {
+	Commitment           []byte   `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PresignRound1Message2) Reset()         { *m = PresignRound1Message2{} }
+func (m *PresignRound1Message2) String() string { return proto.CompactTextString(m) }
+func (*PresignRound1Message2) ProtoMessage()    {}
+func (*PresignRound1Message2) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9fc566df8122cad, []int{1}
+}
+
+func (m *PresignRound1Message2) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PresignRound1Message2.Unmarshal(m, b)
+}
+func (m *PresignRound1Message2) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PresignRound1Message2.Marshal(b, m, deterministic)
+}
+func (m *PresignRound1Message2) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresignRound1Message2.Merge(m, src)
+}
+func (m *PresignRound1Message2) XXX_Size() int {
+	return xxx_messageInfo_PresignRound1Message2.Size(m)
+}
+func (m *PresignRound1Message2) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresignRound1Message2.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresignRound1Message2 proto.InternalMessageInfo
+
+func (m *PresignRound1Message2) GetCommitment() []byte {
+	if m != nil {
+		return m.Commitment
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 2 of the ECDSA TSS presigning protocol,
+// one entry of each field per presignature.
+type PresignRound2Message struct {
+	C1                   [][]byte `protobuf:"bytes,1,rep,name=c1,proto3" json:"c1,omitempty"`
+	C2                   [][]byte `protobuf:"bytes,2,rep,name=c2,proto3" json:"c2,omitempty"`
+	ProofBob             [][]byte `protobuf:"bytes,3,rep,name=proof_bob,json=proofBob,proto3" json:"proof_bob,omitempty"`
+	ProofBobWc           [][]byte `protobuf:"bytes,4,rep,name=proof_bob_wc,json=proofBobWc,proto3" json:"proof_bob_wc,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PresignRound2Message) Reset()         { *m = PresignRound2Message{} }
+func (m *PresignRound2Message) String() string { return proto.CompactTextString(m) }
+func (*PresignRound2Message) ProtoMessage()    {}
+func (*PresignRound2Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9fc566df8122cad, []int{2}
+}
+
+func (m *PresignRound2Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PresignRound2Message.Unmarshal(m, b)
+}
+func (m *PresignRound2Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PresignRound2Message.Marshal(b, m, deterministic)
+}
+func (m *PresignRound2Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresignRound2Message.Merge(m, src)
+}
+func (m *PresignRound2Message) XXX_Size() int {
+	return xxx_messageInfo_PresignRound2Message.Size(m)
+}
+func (m *PresignRound2Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresignRound2Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresignRound2Message proto.InternalMessageInfo
+
+func (m *PresignRound2Message) GetC1() [][]byte {
+	if m != nil {
+		return m.C1
+	}
+	return nil
+}
+
+func (m *PresignRound2Message) GetC2() [][]byte {
+	if m != nil {
+		return m.C2
+	}
+	return nil
+}
+
+func (m *PresignRound2Message) GetProofBob() [][]byte {
+	if m != nil {
+		return m.ProofBob
+	}
+	return nil
+}
+
+func (m *PresignRound2Message) GetProofBobWc() [][]byte {
+	if m != nil {
+		return m.ProofBobWc
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 3 of the ECDSA TSS presigning protocol,
+// one entry of each field per presignature.
+type PresignRound3Message struct {
+	Theta                [][]byte `protobuf:"bytes,1,rep,name=theta,proto3" json:"theta,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PresignRound3Message) Reset()         { *m = PresignRound3Message{} }
+func (m *PresignRound3Message) String() string { return proto.CompactTextString(m) }
+func (*PresignRound3Message) ProtoMessage()    {}
+func (*PresignRound3Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9fc566df8122cad, []int{3}
+}
+
+func (m *PresignRound3Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PresignRound3Message.Unmarshal(m, b)
+}
+func (m *PresignRound3Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PresignRound3Message.Marshal(b, m, deterministic)
+}
+func (m *PresignRound3Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresignRound3Message.Merge(m, src)
+}
+func (m *PresignRound3Message) XXX_Size() int {
+	return xxx_messageInfo_PresignRound3Message.Size(m)
+}
+func (m *PresignRound3Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresignRound3Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresignRound3Message proto.InternalMessageInfo
+
+func (m *PresignRound3Message) GetTheta() [][]byte {
+	if m != nil {
+		return m.Theta
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 4 of the ECDSA TSS presigning protocol,
+// one entry of each proof field per presignature.
+type PresignRound4Message struct {
+	DeCommitment         [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
+	ProofAlphaX          [][]byte `protobuf:"bytes,2,rep,name=proof_alpha_x,json=proofAlphaX,proto3" json:"proof_alpha_x,omitempty"`
+	ProofAlphaY          [][]byte `protobuf:"bytes,3,rep,name=proof_alpha_y,json=proofAlphaY,proto3" json:"proof_alpha_y,omitempty"`
+	ProofT               [][]byte `protobuf:"bytes,4,rep,name=proof_t,json=proofT,proto3" json:"proof_t,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PresignRound4Message) Reset()         { *m = PresignRound4Message{} }
+func (m *PresignRound4Message) String() string { return proto.CompactTextString(m) }
+func (*PresignRound4Message) ProtoMessage()    {}
+func (*PresignRound4Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9fc566df8122cad, []int{4}
+}
+
+func (m *PresignRound4Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PresignRound4Message.Unmarshal(m, b)
+}
+func (m *PresignRound4Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PresignRound4Message.Marshal(b, m, deterministic)
+}
+func (m *PresignRound4Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresignRound4Message.Merge(m, src)
+}
+func (m *PresignRound4Message) XXX_Size() int {
+	return xxx_messageInfo_PresignRound4Message.Size(m)
+}
+func (m *PresignRound4Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresignRound4Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresignRound4Message proto.InternalMessageInfo
+
+func (m *PresignRound4Message) GetDeCommitment() [][]byte {
+	if m != nil {
+		return m.DeCommitment
+	}
+	return nil
+}
+
+func (m *PresignRound4Message) GetProofAlphaX() [][]byte {
+	if m != nil {
+		return m.ProofAlphaX
+	}
+	return nil
+}
+
+func (m *PresignRound4Message) GetProofAlphaY() [][]byte {
+	if m != nil {
+		return m.ProofAlphaY
+	}
+	return nil
+}
+
+func (m *PresignRound4Message) GetProofT() [][]byte {
+	if m != nil {
+		return m.ProofT
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 5 of the ECDSA TSS presigning protocol,
+// the only round that depends on the messages, one signature share per message.
+type PresignRound5Message struct {
+	S                    [][]byte `protobuf:"bytes,1,rep,name=s,proto3" json:"s,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PresignRound5Message) Reset()         { *m = PresignRound5Message{} }
+func (m *PresignRound5Message) String() string { return proto.CompactTextString(m) }
+func (*PresignRound5Message) ProtoMessage()    {}
+func (*PresignRound5Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9fc566df8122cad, []int{5}
+}
+
+func (m *PresignRound5Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PresignRound5Message.Unmarshal(m, b)
+}
+func (m *PresignRound5Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PresignRound5Message.Marshal(b, m, deterministic)
+}
+func (m *PresignRound5Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresignRound5Message.Merge(m, src)
+}
+func (m *PresignRound5Message) XXX_Size() int {
+	return xxx_messageInfo_PresignRound5Message.Size(m)
+}
+func (m *PresignRound5Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresignRound5Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresignRound5Message proto.InternalMessageInfo
+
+func (m *PresignRound5Message) GetS() [][]byte {
+	if m != nil {
+		return m.S
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*PresignRound1Message1)(nil), "binance.tsslib.ecdsa.presign.PresignRound1Message1")
+	proto.RegisterType((*PresignRound1Message2)(nil), "binance.tsslib.ecdsa.presign.PresignRound1Message2")
+	proto.RegisterType((*PresignRound2Message)(nil), "binance.tsslib.ecdsa.presign.PresignRound2Message")
+	proto.RegisterType((*PresignRound3Message)(nil), "binance.tsslib.ecdsa.presign.PresignRound3Message")
+	proto.RegisterType((*PresignRound4Message)(nil), "binance.tsslib.ecdsa.presign.PresignRound4Message")
+	proto.RegisterType((*PresignRound5Message)(nil), "binance.tsslib.ecdsa.presign.PresignRound5Message")
+}
+
+func init() { proto.RegisterFile("protob/ecdsa-presign.proto", fileDescriptor_a9fc566df8122cad) }
+
+var fileDescriptor_a9fc566df8122cad = []byte{
+	// 330 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x5d, 0x4b, 0xc3, 0x30,
+	0x14, 0x25, 0x9d, 0x4e, 0xbd, 0x76, 0x0e, 0xc3, 0xc4, 0xa2, 0x22, 0x23, 0xfa, 0x30, 0x44, 0x3b,
+	0xda, 0x29, 0x3e, 0x3b, 0x9f, 0x85, 0x39, 0x04, 0x3f, 0x5e, 0x4a, 0x92, 0xc6, 0xad, 0xb0, 0x35,
+	0xa5, 0xc9, 0x50, 0xff, 0x8b, 0x3f, 0x56, 0x96, 0x26, 0x63, 0x1d, 0xbe, 0xf5, 0x9e, 0x73, 0x6e,
+	0xcf, 0xb9, 0x37, 0x17, 0x4e, 0x8a, 0x52, 0x6a, 0xc9, 0xfa, 0x82, 0xa7, 0x8a, 0xde, 0x14, 0xa5,
+	0x50, 0xd9, 0x24, 0x0f, 0x0d, 0x88, 0xcf, 0x58, 0x96, 0xd3, 0x9c, 0x8b, 0x50, 0x2b, 0x35, 0xcb,
+	0x58, 0x68, 0x34, 0xa1, 0xd5, 0x90, 0x67, 0x38, 0x1a, 0x55, 0x9f, 0x63, 0xb9, 0xc8, 0xd3, 0xe8,
+	0x49, 0x28, 0x45, 0x27, 0x22, 0xc2, 0x3e, 0x20, 0x1e, 0xa0, 0x6e, 0xa3, 0xe7, 0x8f, 0x11, 0xc7,
+	0x57, 0x70, 0x58, 0xd2, 0x7c, 0x22, 0x92, 0xa2, 0x94, 0xf2, 0x33, 0xa1, 0xb3, 0x8c, 0x8b, 0xc0,
+	0x33, 0x6c, 0xdb, 0x10, 0xa3, 0x25, 0xfe, 0xb0, 0x84, 0xc9, 0xfd, 0xff, 0xbf, 0x8c, 0xf1, 0x39,
+	0x00, 0x97, 0xf3, 0x79, 0xa6, 0xe7, 0x22, 0xd7, 0x01, 0xea, 0xa2, 0x9e, 0x3f, 0x5e, 0x43, 0xc8,
+	0x02, 0x3a, 0xeb, 0x8d, 0xb1, 0x6d, 0xc4, 0x07, 0xe0, 0xf1, 0xc8, 0x66, 0xf1, 0x78, 0x64, 0xea,
+	0xd8, 0xba, 0x7b, 0x3c, 0xc6, 0xa7, 0xb0, 0x57, 0xc5, 0x62, 0x92, 0x05, 0x0d, 0x03, 0xef, 0x1a,
+	0x60, 0x28, 0x19, 0xee, 0x82, 0xbf, 0x22, 0x93, 0x2f, 0x1e, 0x6c, 0x19, 0x1e, 0x1c, 0xff, 0xca,
+	0xc9, 0x75, 0xdd, 0x76, 0xe0, 0x6c, 0x3b, 0xb0, 0xad, 0xa7, 0x42, 0x53, 0xeb, 0x5c, 0x15, 0xe4,
+	0x17, 0xd5, 0xe5, 0xb7, 0x4e, 0x7e, 0x01, 0xad, 0x54, 0x24, 0xb5, 0x01, 0x97, 0x6d, 0x7e, 0x2a,
+	0x1e, 0x57, 0x18, 0x26, 0xd0, 0x72, 0x1b, 0x2c, 0xa6, 0x34, 0xf9, 0xb6, 0x53, 0xec, 0x17, 0xd5,
+	0xfa, 0x8a, 0x29, 0x7d, 0xdb, 0xd4, 0xfc, 0x04, 0x8d, 0x4d, 0xcd, 0x3b, 0x3e, 0x86, 0x9d, 0x4a,
+	0xa3, 0xed, 0x40, 0x4d, 0x53, 0xbe, 0x90, 0xcb, 0x7a, 0xba, 0x3b, 0x97, 0xce, 0x07, 0xa4, 0xdc,
+	0x73, 0xaa, 0x61, 0xfb, 0xa3, 0x65, 0xce, 0xa0, 0x6f, 0xcf, 0x80, 0x35, 0xcd, 0xad, 0x0c, 0xfe,
+	0x06, 0x00, 0x7a, 0x99, 0xda, 0x89, 0x49, 0x02, 0x00, 0x00,
+}
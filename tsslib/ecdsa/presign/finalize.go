@@ -0,0 +1,109 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/tss"
+)
+
+// the finalization sums the shares of every message, a message whose
+// signature does not verify fails on its own
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 6
+	round.started = true
+	round.resetOK()
+
+	pub := round.key.ECDSAPub
+	if round.temp.tweak != nil {
+		var err error
+		if pub, err = pub.Add(crypto.ScalarBaseMult(round.EC(), round.temp.tweak)); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "ECDSAPub.Add(tweak)"))
+		}
+	}
+	pk := ecdsa.PublicKey{
+		Curve: round.EC(),
+		X:     pub.X(),
+		Y:     pub.Y(),
+	}
+
+	N := round.EC().Params().N
+	modN := common.ModInt(N)
+	sumSs := make([]*big.Int, round.temp.count)
+	copy(sumSs, round.temp.si)
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r5msg := round.temp.presignRound5Messages[j].Content().(*PresignRound5Message)
+		for b, sj := range r5msg.UnmarshalS() {
+			sumSs[b] = modN.Add(sumSs[b], sj)
+		}
+	}
+
+	sigs := make([]Signature, round.temp.count)
+	for b, sumS := range sumSs {
+		m := round.temp.msgs[b]
+		rx, ry := round.temp.bigR[b].X(), round.temp.bigR[b].Y()
+		r := new(big.Int).Mod(rx, N)
+
+		recid := 0
+		// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+		if rx.Cmp(N) > 0 {
+			recid = 2
+		}
+		if ry.Bit(0) != 0 {
+			recid |= 1
+		}
+		// the low S form, as the signing of GG18 makes it
+		halfN := new(big.Int).Rsh(N, 1)
+		if sumS.Cmp(halfN) > 0 {
+			sumS = new(big.Int).Sub(N, sumS)
+			recid ^= 1
+		}
+
+		if !ecdsa.Verify(&pk, m.Bytes(), r, sumS) {
+			sigs[b] = Signature{Err: errors.New("signature verification failed")}
+			continue
+		}
+		sigs[b] = Signature{Data: &common.SignatureData{
+			Signature:         append(r.Bytes(), sumS.Bytes()...),
+			SignatureRecovery: []byte{byte(recid)},
+			R:                 r.Bytes(),
+			S:                 sumS.Bytes(),
+			M:                 m.Bytes(),
+		}}
+	}
+	round.sigEnd <- sigs
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,267 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	cmt "CipherMachine/tsslib/crypto/commitments"
+	"CipherMachine/tsslib/crypto/mta"
+	"CipherMachine/tsslib/ecdsa/keygen"
+	"CipherMachine/tsslib/ecdsa/signing"
+	"CipherMachine/tsslib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys keygen.LocalPartySaveData
+		temp localTempData
+
+		// outbound messaging
+		out    chan<- tss.Message
+		end    chan<- []Presignature
+		sigEnd chan<- []Signature
+	}
+
+	localMessageStore struct {
+		presignRound1Message1s,
+		presignRound1Message2s,
+		presignRound2Messages,
+		presignRound3Messages,
+		presignRound4Messages,
+		presignRound5Messages []tss.ParsedMessage
+	}
+
+	// the vectors of the offline rounds hold one entry per presignature, the
+	// ones of the parties one vector per party
+	localTempData struct {
+		localMessageStore
+
+		// number of presignatures of the session
+		count int
+
+		// temp data (thrown away after presign) / round 1
+		w     *big.Int
+		bigWs []*crypto.ECPoint
+		k,
+		gamma []*big.Int
+		pointGamma []*crypto.ECPoint
+		deCommit   cmt.HashDeCommitment
+		cis        [][]*big.Int
+
+		// round 2
+		betas, // return value of Bob_mid
+		c1jis,
+		c2jis,
+		vs [][]*big.Int // return value of Bob_mid_wc
+		pi1jis [][]*mta.ProofBob
+		pi2jis [][]*mta.ProofBobWC
+
+		// round 3
+		theta,
+		sigma []*big.Int
+
+		// round 4
+		thetaInverse []*big.Int
+
+		// round 5, the online round. tweak is the offset of the child key
+		// that signs, nil for the key itself
+		msgs    []*big.Int
+		tweak   *big.Int
+		presigs []Presignature
+		bigR    []*crypto.ECPoint
+		si      []*big.Int
+	}
+)
+
+// NewLocalParty returns a party that makes count presignatures of key for the
+// parties of params, it ends with them on end.
+func NewLocalParty(
+	count int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- []Presignature,
+) tss.Party {
+	p := newLocalParty(params, key, out)
+	p.temp.count = count
+	p.end = end
+	return p
+}
+
+// NewSigningParty returns a party that signs msgs, the child key at tweak
+// signs if tweak is not nil. It signs every message with the next one of
+// presigs and finishes in a single round, without presigs it makes them for
+// msgs first in the same session. It ends with the signatures in the order
+// of msgs. With presigs the party has a single round, it must be started
+// before it is updated with the msgs of the other parties.
+func NewSigningParty(
+	msgs []*big.Int,
+	tweak *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	presigs []Presignature,
+	out chan<- tss.Message,
+	end chan<- []Signature,
+) tss.Party {
+	p := newLocalParty(params, key, out)
+	p.temp.count = len(msgs)
+	p.temp.msgs = msgs
+	p.temp.tweak = tweak
+	p.temp.presigs = presigs
+	p.sigEnd = end
+	return p
+}
+
+func newLocalParty(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message) *LocalParty {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:      localTempData{},
+		out:       out,
+	}
+	// msgs init
+	p.temp.presignRound1Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound1Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound2Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound3Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound4Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound5Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.bigWs = make([]*crypto.ECPoint, partyCount)
+	p.temp.cis = make([][]*big.Int, partyCount)
+	p.temp.betas = make([][]*big.Int, partyCount)
+	p.temp.c1jis = make([][]*big.Int, partyCount)
+	p.temp.c2jis = make([][]*big.Int, partyCount)
+	p.temp.pi1jis = make([][]*mta.ProofBob, partyCount)
+	p.temp.pi2jis = make([][]*mta.ProofBobWC, partyCount)
+	p.temp.vs = make([][]*big.Int, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	r1 := newRound1(p.params, &p.keys, &p.temp, p.out, p.end, p.sigEnd)
+	if p.temp.presigs != nil {
+		return &round5{&round4{&round3{&round2{r1.(*round1)}}}}
+	}
+	return r1
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		switch round := round.(type) {
+		case *round1:
+			if err := round.prepare(); err != nil {
+				return round.WrapError(err)
+			}
+		case *round5:
+			if len(p.temp.presigs) != len(p.temp.msgs) {
+				return round.WrapError(fmt.Errorf("%d presignatures for %d messages", len(p.temp.presigs), len(p.temp.msgs)))
+			}
+		default:
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		return nil
+	})
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *PresignRound1Message1:
+		p.temp.presignRound1Message1s[fromPIdx] = msg
+	case *PresignRound1Message2:
+		p.temp.presignRound1Message2s[fromPIdx] = msg
+	case *PresignRound2Message:
+		p.temp.presignRound2Messages[fromPIdx] = msg
+	case *PresignRound3Message:
+		p.temp.presignRound3Messages[fromPIdx] = msg
+	case *PresignRound4Message:
+		p.temp.presignRound4Messages[fromPIdx] = msg
+	case *PresignRound5Message:
+		p.temp.presignRound5Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// prepare computes the share w of the key in the additive sharing among the
+// signing parties, as the signing of GG18 does.
+func (round *round1) prepare() error {
+	i := round.PartyID().Index
+
+	xi := round.key.Xi
+	ks := round.key.Ks
+	bigXs := round.key.BigXj
+
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	if round.temp.count < 1 {
+		return errors.New("nothing to presign")
+	}
+	wi, bigWs := signing.PrepareForSigning(round.EC(), i, len(ks), xi, ks, bigXs)
+
+	round.temp.w = wi
+	round.temp.bigWs = bigWs
+	return nil
+}
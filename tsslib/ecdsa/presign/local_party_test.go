@@ -0,0 +1,212 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/ecdsa/keygen"
+	"CipherMachine/tsslib/test"
+	"CipherMachine/tsslib/tss"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// loadKeys loads the keys of the two parties of the threshold fixtures.
+func loadKeys(t *testing.T) ([]keygen.LocalPartySaveData, tss.SortedPartyIDs) {
+	root := "../../../threshold/test"
+	keys := make([]keygen.LocalPartySaveData, 2)
+	for i := range keys {
+		bz, err := ioutil.ReadFile(filepath.Join(root, fmt.Sprintf("keygen_data_%d.json", i)))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		if !assert.NoError(t, json.Unmarshal(bz, &keys[i])) {
+			t.FailNow()
+		}
+	}
+	ids := make(tss.UnSortedPartyIDs, 0, 2)
+	for i, id := range []string{"bd6d6460d07980f17ec433d3bb401ecbe2ae2472", "8cf7506978b5f74ba44c50f2c41b6ac6ac982149"} {
+		key, _ := hex.DecodeString(id)
+		ids = append(ids, &tss.PartyID{
+			MessageWrapper_PartyID: &tss.MessageWrapper_PartyID{Id: id, Moniker: fmt.Sprintf("tss-peer[%d]", i+1), Key: key},
+			Index:                  i,
+		})
+	}
+	return keys, tss.SortPartyIDs(ids)
+}
+
+// runParties starts parties and routes their msgs until done returns true.
+// The parties are started before any msg is routed, a party that signs with
+// its presignatures would miss the msgs it got before it started.
+func runParties(t *testing.T, parties []tss.Party, outCh chan tss.Message, errCh chan *tss.Error, done func() bool) {
+	updater := test.SharedPartyUpdater
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+	for !done() {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-time.After(10 * time.Millisecond):
+			// the parties ended, done checks their end channels again
+		}
+	}
+}
+
+// signAll signs msgs with presigs, nil presigs presign in the same session,
+// and returns the signatures of every party.
+func signAll(t *testing.T, keys []keygen.LocalPartySaveData, pIDs tss.SortedPartyIDs, msgs []*big.Int, tweak *big.Int, presigs [][]Presignature) [][]Signature {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, 2*len(pIDs))
+	endCh := make(chan []Signature, len(pIDs))
+	parties := make([]tss.Party, 0, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(tss.EC(), p2pCtx, pIDs[i], len(pIDs), 1)
+		var mine []Presignature
+		if presigs != nil {
+			mine = presigs[i]
+		}
+		parties = append(parties, NewSigningParty(msgs, tweak, params, keys[i], mine, outCh, endCh))
+	}
+	var sigs [][]Signature
+	runParties(t, parties, outCh, errCh, func() bool {
+		select {
+		case s := <-endCh:
+			sigs = append(sigs, s)
+		default:
+		}
+		return len(sigs) == len(pIDs)
+	})
+	return sigs
+}
+
+func assertSigned(t *testing.T, pub *crypto.ECPoint, msgs []*big.Int, sigs []Signature) {
+	pk := ecdsa.PublicKey{Curve: tss.EC(), X: pub.X(), Y: pub.Y()}
+	if !assert.Len(t, sigs, len(msgs)) {
+		return
+	}
+	for b, sig := range sigs {
+		if !assert.NoError(t, sig.Err) {
+			continue
+		}
+		r, s := new(big.Int).SetBytes(sig.Data.R), new(big.Int).SetBytes(sig.Data.S)
+		assert.True(t, ecdsa.Verify(&pk, msgs[b].Bytes(), r, s), "ecdsa verify must pass")
+		assert.Equal(t, msgs[b].Bytes(), sig.Data.M)
+	}
+}
+
+func TestE2EPresignThenSign(t *testing.T) {
+	setUp("info")
+	keys, pIDs := loadKeys(t)
+
+	// PHASE: presign
+	p2pCtx := tss.NewPeerContext(pIDs)
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, 2*len(pIDs))
+	// an end channel per party, the presignatures are of the party
+	endChs := make([]chan []Presignature, len(pIDs))
+	parties := make([]tss.Party, 0, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(tss.EC(), p2pCtx, pIDs[i], len(pIDs), 1)
+		endChs[i] = make(chan []Presignature, 1)
+		parties = append(parties, NewLocalParty(3, params, keys[i], outCh, endChs[i]))
+	}
+	presigs := make([][]Presignature, len(pIDs))
+	ended := 0
+	runParties(t, parties, outCh, errCh, func() bool {
+		for i, endCh := range endChs {
+			select {
+			case presigs[i] = <-endCh:
+				assert.Len(t, presigs[i], 3)
+				ended++
+			default:
+			}
+		}
+		return ended == len(pIDs)
+	})
+	for b := 0; b < 3; b++ {
+		// every party sees the same R
+		assert.True(t, presigs[0][b].R.Equals(presigs[1][b].R))
+	}
+
+	// PHASE: online signing, one round with the presignatures
+	msgs := []*big.Int{big.NewInt(42), big.NewInt(43)}
+	sigs := signAll(t, keys, pIDs, msgs, nil, [][]Presignature{presigs[0][:2], presigs[1][:2]})
+	for _, s := range sigs {
+		assertSigned(t, keys[0].ECDSAPub, msgs, s)
+	}
+
+	// the child key at tweak signs with the last presignature
+	tweak := big.NewInt(7)
+	child, err := keys[0].ECDSAPub.Add(crypto.ScalarBaseMult(tss.EC(), tweak))
+	assert.NoError(t, err)
+	msgs = []*big.Int{big.NewInt(44)}
+	sigs = signAll(t, keys, pIDs, msgs, tweak, [][]Presignature{presigs[0][2:], presigs[1][2:]})
+	for _, s := range sigs {
+		assertSigned(t, child, msgs, s)
+	}
+}
+
+func TestE2EBatch(t *testing.T) {
+	setUp("info")
+	keys, pIDs := loadKeys(t)
+
+	msgs := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	sigs := signAll(t, keys, pIDs, msgs, nil, nil)
+	for _, s := range sigs {
+		assertSigned(t, keys[0].ECDSAPub, msgs, s)
+	}
+
+	// the child key at tweak signs the batch
+	tweak := big.NewInt(7)
+	child, err := keys[0].ECDSAPub.Add(crypto.ScalarBaseMult(tss.EC(), tweak))
+	assert.NoError(t, err)
+	msgs = []*big.Int{big.NewInt(44), big.NewInt(45)}
+	sigs = signAll(t, keys, pIDs, msgs, tweak, nil)
+	for _, s := range sigs {
+		assertSigned(t, child, msgs, s)
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	cmt "CipherMachine/tsslib/crypto/commitments"
+	"CipherMachine/tsslib/crypto/mta"
+	"CipherMachine/tsslib/crypto/schnorr"
+	"CipherMachine/tsslib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-presign.pb.go
+// Every message carries one entry per presignature, or per message in round 5.
+// The proofs of a presignature are flattened into the repeated fields one
+// after another.
+
+var (
+	// Ensure that presigning messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*PresignRound1Message1)(nil),
+		(*PresignRound1Message2)(nil),
+		(*PresignRound2Message)(nil),
+		(*PresignRound3Message)(nil),
+		(*PresignRound4Message)(nil),
+		(*PresignRound5Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewPresignRound1Message1(
+	to, from *tss.PartyID,
+	cs []*big.Int,
+	proofs []*mta.RangeProofAlice,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfBzs := make([][]byte, 0, len(proofs)*mta.RangeProofAliceBytesParts)
+	for _, proof := range proofs {
+		pfBz := proof.Bytes()
+		pfBzs = append(pfBzs, pfBz[:]...)
+	}
+	content := &PresignRound1Message1{
+		C:               common.BigIntsToBytes(cs),
+		RangeProofAlice: pfBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound1Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetC()) &&
+		common.NonEmptyMultiBytes(m.GetRangeProofAlice(), len(m.GetC())*mta.RangeProofAliceBytesParts)
+}
+
+func (m *PresignRound1Message1) Count() int {
+	return len(m.GetC())
+}
+
+func (m *PresignRound1Message1) UnmarshalC(b int) *big.Int {
+	return new(big.Int).SetBytes(m.GetC()[b])
+}
+
+func (m *PresignRound1Message1) UnmarshalRangeProofAlice(b int) (*mta.RangeProofAlice, error) {
+	parts := mta.RangeProofAliceBytesParts
+	return mta.RangeProofAliceFromBytes(m.GetRangeProofAlice()[b*parts : (b+1)*parts])
+}
+
+// ----- //
+
+func NewPresignRound1Message2(
+	from *tss.PartyID,
+	commitment cmt.HashCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound1Message2{
+		Commitment: commitment.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound1Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *PresignRound1Message2) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+// ----- //
+
+func NewPresignRound2Message(
+	to, from *tss.PartyID,
+	c1Jis []*big.Int,
+	pi1Jis []*mta.ProofBob,
+	c2Jis []*big.Int,
+	pi2Jis []*mta.ProofBobWC,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfBob := make([][]byte, 0, len(pi1Jis)*mta.ProofBobBytesParts)
+	for _, pf := range pi1Jis {
+		bzs := pf.Bytes()
+		pfBob = append(pfBob, bzs[:]...)
+	}
+	pfBobWC := make([][]byte, 0, len(pi2Jis)*mta.ProofBobWCBytesParts)
+	for _, pf := range pi2Jis {
+		bzs := pf.Bytes()
+		pfBobWC = append(pfBobWC, bzs[:]...)
+	}
+	content := &PresignRound2Message{
+		C1:         common.BigIntsToBytes(c1Jis),
+		C2:         common.BigIntsToBytes(c2Jis),
+		ProofBob:   pfBob,
+		ProofBobWc: pfBobWC,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetC1()) &&
+		common.NonEmptyMultiBytes(m.GetC2(), len(m.GetC1())) &&
+		common.NonEmptyMultiBytes(m.GetProofBob(), len(m.GetC1())*mta.ProofBobBytesParts) &&
+		common.NonEmptyMultiBytes(m.GetProofBobWc(), len(m.GetC1())*mta.ProofBobWCBytesParts)
+}
+
+func (m *PresignRound2Message) Count() int {
+	return len(m.GetC1())
+}
+
+func (m *PresignRound2Message) UnmarshalC1(b int) *big.Int {
+	return new(big.Int).SetBytes(m.GetC1()[b])
+}
+
+func (m *PresignRound2Message) UnmarshalC2(b int) *big.Int {
+	return new(big.Int).SetBytes(m.GetC2()[b])
+}
+
+func (m *PresignRound2Message) UnmarshalProofBob(b int) (*mta.ProofBob, error) {
+	parts := mta.ProofBobBytesParts
+	return mta.ProofBobFromBytes(m.GetProofBob()[b*parts : (b+1)*parts])
+}
+
+func (m *PresignRound2Message) UnmarshalProofBobWC(ec elliptic.Curve, b int) (*mta.ProofBobWC, error) {
+	parts := mta.ProofBobWCBytesParts
+	return mta.ProofBobWCFromBytes(ec, m.GetProofBobWc()[b*parts:(b+1)*parts])
+}
+
+// ----- //
+
+func NewPresignRound3Message(
+	from *tss.PartyID,
+	thetas []*big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound3Message{
+		Theta: common.BigIntsToBytes(thetas),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetTheta())
+}
+
+func (m *PresignRound3Message) UnmarshalThetas() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetTheta())
+}
+
+// ----- //
+
+func NewPresignRound4Message(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	proofs []*schnorr.ZKProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound4Message{
+		DeCommitment: common.BigIntsToBytes(deCommitment),
+		ProofAlphaX:  make([][]byte, len(proofs)),
+		ProofAlphaY:  make([][]byte, len(proofs)),
+		ProofT:       make([][]byte, len(proofs)),
+	}
+	for b, proof := range proofs {
+		content.ProofAlphaX[b] = proof.Alpha.X().Bytes()
+		content.ProofAlphaY[b] = proof.Alpha.Y().Bytes()
+		content.ProofT[b] = proof.T.Bytes()
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound4Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment()) &&
+		common.NonEmptyMultiBytes(m.GetProofAlphaX()) &&
+		common.NonEmptyMultiBytes(m.GetProofAlphaY(), len(m.GetProofAlphaX())) &&
+		common.NonEmptyMultiBytes(m.GetProofT(), len(m.GetProofAlphaX()))
+}
+
+func (m *PresignRound4Message) Count() int {
+	return len(m.GetProofAlphaX())
+}
+
+func (m *PresignRound4Message) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
+
+func (m *PresignRound4Message) UnmarshalZKProof(ec elliptic.Curve, b int) (*schnorr.ZKProof, error) {
+	point, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofAlphaX()[b]),
+		new(big.Int).SetBytes(m.GetProofAlphaY()[b]))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(m.GetProofT()[b]),
+	}, nil
+}
+
+// ----- //
+
+func NewPresignRound5Message(
+	from *tss.PartyID,
+	si []*big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound5Message{
+		S: common.BigIntsToBytes(si),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound5Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetS())
+}
+
+func (m *PresignRound5Message) UnmarshalS() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetS())
+}
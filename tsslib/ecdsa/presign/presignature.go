@@ -0,0 +1,37 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"math/big"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+)
+
+// Presignature is the share of a party of the nonce of one future signature,
+// rounds 1-4 of GG18 signing run before the message is known. K and Sigma are
+// secret like the share of the key, and a presignature must sign one message
+// only: two signatures with the same nonce give away the key.
+type Presignature struct {
+	// additive shares of the nonce k and of k*x
+	K,
+	Sigma *big.Int
+	// R = g^(1/k), the x coordinate of R is the r of the signature
+	R *crypto.ECPoint
+	// the public key and the share ids of the signing parties the
+	// presignature was made for, it signs for them only
+	ECDSAPub *crypto.ECPoint
+	Ks       []*big.Int
+}
+
+// Signature is the signature of one message of a batch, or the error the
+// message failed with. The other messages of the batch are signed anyway.
+type Signature struct {
+	Data *common.SignatureData
+	Err  error
+}
@@ -0,0 +1,124 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/crypto/commitments"
+	"CipherMachine/tsslib/crypto/mta"
+	"CipherMachine/tsslib/ecdsa/keygen"
+	"CipherMachine/tsslib/tss"
+)
+
+var (
+	zero = big.NewInt(0)
+)
+
+// round 1 represents round 1 of the signing part of the GG18 ECDSA TSS spec
+// (Gennaro, Goldfeder; 2018), run for every presignature of the session at once
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- []Presignature, sigEnd chan<- []Signature) tss.Round {
+	return &round1{
+		&base{params, key, temp, out, end, sigEnd, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	// the messages are checked before any presignature is made for them
+	for b, m := range round.temp.msgs {
+		if m.Cmp(round.EC().Params().N) >= 0 {
+			return round.WrapError(fmt.Errorf("hashed message %d is not valid", b))
+		}
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	count := round.temp.count
+	round.temp.k = make([]*big.Int, count)
+	round.temp.gamma = make([]*big.Int, count)
+	round.temp.pointGamma = make([]*crypto.ECPoint, count)
+	// one commitment to all the gamma points
+	secrets := make([]*big.Int, 0, 2*count)
+	for b := 0; b < count; b++ {
+		round.temp.k[b] = common.GetRandomPositiveInt(round.EC().Params().N)
+		round.temp.gamma[b] = common.GetRandomPositiveInt(round.EC().Params().N)
+		round.temp.pointGamma[b] = crypto.ScalarBaseMult(round.EC(), round.temp.gamma[b])
+		secrets = append(secrets, round.temp.pointGamma[b].X(), round.temp.pointGamma[b].Y())
+	}
+	cmt := commitments.NewHashCommitment(secrets...)
+	round.temp.deCommit = cmt.D
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		cAs := make([]*big.Int, count)
+		pis := make([]*mta.RangeProofAlice, count)
+		for b := 0; b < count; b++ {
+			cA, pi, err := mta.AliceInit(round.EC(), round.key.PaillierPKs[i], round.temp.k[b], round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j])
+			if err != nil {
+				return round.WrapError(fmt.Errorf("failed to init mta: %v", err))
+			}
+			cAs[b], pis[b] = cA, pi
+		}
+		r1msg1 := NewPresignRound1Message1(Pj, round.PartyID(), cAs, pis)
+		round.temp.cis[j] = cAs
+		round.out <- r1msg1
+	}
+
+	r1msg2 := NewPresignRound1Message2(round.PartyID(), cmt.C)
+	round.temp.presignRound1Message2s[i] = r1msg2
+	round.out <- r1msg2
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	for j, msg1 := range round.temp.presignRound1Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg1 == nil || !round.CanAccept(msg1) {
+			return false, nil
+		}
+		msg2 := round.temp.presignRound1Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		if msg1.Content().(*PresignRound1Message1).Count() != round.temp.count {
+			return false, round.WrapError(errors.New("wrong number of presignatures"), msg1.GetFrom())
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound1Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound1Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
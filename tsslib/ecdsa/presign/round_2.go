@@ -0,0 +1,174 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	errorspkg "github.com/pkg/errors"
+
+	"CipherMachine/tsslib/crypto/mta"
+	"CipherMachine/tsslib/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+	count := round.temp.count
+
+	for j := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		round.temp.betas[j] = make([]*big.Int, count)
+		round.temp.c1jis[j] = make([]*big.Int, count)
+		round.temp.pi1jis[j] = make([]*mta.ProofBob, count)
+		round.temp.vs[j] = make([]*big.Int, count)
+		round.temp.c2jis[j] = make([]*big.Int, count)
+		round.temp.pi2jis[j] = make([]*mta.ProofBobWC, count)
+	}
+
+	errChs := make(chan *tss.Error, (len(round.Parties().IDs())-1)*2*count)
+	wg := sync.WaitGroup{}
+	wg.Add((len(round.Parties().IDs()) - 1) * 2 * count)
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r1msg := round.temp.presignRound1Message1s[j].Content().(*PresignRound1Message1)
+		for b := 0; b < count; b++ {
+			// Bob_mid
+			go func(j, b int, Pj *tss.PartyID) {
+				defer wg.Done()
+				rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice(b)
+				if err != nil {
+					errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
+					return
+				}
+				beta, c1ji, _, pi1ji, err := mta.BobMid(
+					round.EC(),
+					round.key.PaillierPKs[j],
+					rangeProofAliceJ,
+					round.temp.gamma[b],
+					r1msg.UnmarshalC(b),
+					round.key.NTildej[j],
+					round.key.H1j[j],
+					round.key.H2j[j],
+					round.key.NTildej[i],
+					round.key.H1j[i],
+					round.key.H2j[i])
+				// should be thread safe as these are pre-allocated
+				round.temp.betas[j][b] = beta
+				round.temp.c1jis[j][b] = c1ji
+				round.temp.pi1jis[j][b] = pi1ji
+				if err != nil {
+					errChs <- round.WrapError(err, Pj)
+				}
+			}(j, b, Pj)
+			// Bob_mid_wc
+			go func(j, b int, Pj *tss.PartyID) {
+				defer wg.Done()
+				rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice(b)
+				if err != nil {
+					errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
+					return
+				}
+				v, c2ji, _, pi2ji, err := mta.BobMidWC(
+					round.EC(),
+					round.key.PaillierPKs[j],
+					rangeProofAliceJ,
+					round.temp.w,
+					r1msg.UnmarshalC(b),
+					round.key.NTildej[j],
+					round.key.H1j[j],
+					round.key.H2j[j],
+					round.key.NTildej[i],
+					round.key.H1j[i],
+					round.key.H2j[i],
+					round.temp.bigWs[i])
+				round.temp.vs[j][b] = v
+				round.temp.c2jis[j][b] = c2ji
+				round.temp.pi2jis[j][b] = pi2ji
+				if err != nil {
+					errChs <- round.WrapError(err, Pj)
+				}
+			}(j, b, Pj)
+		}
+	}
+	// consume error channels; wait for goroutines
+	wg.Wait()
+	close(errChs)
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for err := range errChs {
+		culprits = appendCulprits(culprits, err.Culprits()...)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to calculate Bob_mid or Bob_mid_wc"), culprits...)
+	}
+	// create and send messages
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r2msg := NewPresignRound2Message(
+			Pj, round.PartyID(), round.temp.c1jis[j], round.temp.pi1jis[j], round.temp.c2jis[j], round.temp.pi2jis[j])
+		round.out <- r2msg
+	}
+	return nil
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.presignRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		if msg.Content().(*PresignRound2Message).Count() != round.temp.count {
+			return false, round.WrapError(errors.New("wrong number of presignatures"), msg.GetFrom())
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
+
+// appendCulprits appends the parties of more that are not in culprits yet, a
+// party fails every presignature of a bad message.
+func appendCulprits(culprits []*tss.PartyID, more ...*tss.PartyID) []*tss.PartyID {
+outer:
+	for _, P := range more {
+		for _, C := range culprits {
+			if C == P {
+				continue outer
+			}
+		}
+		culprits = append(culprits, P)
+	}
+	return culprits
+}
@@ -0,0 +1,159 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	errorspkg "github.com/pkg/errors"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto/mta"
+	"CipherMachine/tsslib/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	count := round.temp.count
+
+	var alphas = make([][]*big.Int, len(round.Parties().IDs()))
+	var us = make([][]*big.Int, len(round.Parties().IDs()))
+	for j := range round.Parties().IDs() {
+		alphas[j] = make([]*big.Int, count)
+		us[j] = make([]*big.Int, count)
+	}
+
+	errChs := make(chan *tss.Error, (len(round.Parties().IDs())-1)*2*count)
+	wg := sync.WaitGroup{}
+	wg.Add((len(round.Parties().IDs()) - 1) * 2 * count)
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r2msg := round.temp.presignRound2Messages[j].Content().(*PresignRound2Message)
+		for b := 0; b < count; b++ {
+			// Alice_end
+			go func(j, b int, Pj *tss.PartyID) {
+				defer wg.Done()
+				proofBob, err := r2msg.UnmarshalProofBob(b)
+				if err != nil {
+					errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBob failed"), Pj)
+					return
+				}
+				alphaIj, err := mta.AliceEnd(
+					round.EC(),
+					round.key.PaillierPKs[i],
+					proofBob,
+					round.key.H1j[i],
+					round.key.H2j[i],
+					round.temp.cis[j][b],
+					r2msg.UnmarshalC1(b),
+					round.key.NTildej[i],
+					round.key.PaillierSK)
+				alphas[j][b] = alphaIj
+				if err != nil {
+					errChs <- round.WrapError(err, Pj)
+				}
+			}(j, b, Pj)
+			// Alice_end_wc
+			go func(j, b int, Pj *tss.PartyID) {
+				defer wg.Done()
+				proofBobWC, err := r2msg.UnmarshalProofBobWC(round.EC(), b)
+				if err != nil {
+					errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBobWC failed"), Pj)
+					return
+				}
+				uIj, err := mta.AliceEndWC(
+					round.EC(),
+					round.key.PaillierPKs[i],
+					proofBobWC,
+					round.temp.bigWs[j],
+					round.temp.cis[j][b],
+					r2msg.UnmarshalC2(b),
+					round.key.NTildej[i],
+					round.key.H1j[i],
+					round.key.H2j[i],
+					round.key.PaillierSK)
+				us[j][b] = uIj
+				if err != nil {
+					errChs <- round.WrapError(err, Pj)
+				}
+			}(j, b, Pj)
+		}
+	}
+
+	// consume error channels; wait for goroutines
+	wg.Wait()
+	close(errChs)
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for err := range errChs {
+		culprits = appendCulprits(culprits, err.Culprits()...)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to calculate Alice_end or Alice_end_wc"), culprits...)
+	}
+
+	modN := common.ModInt(round.EC().Params().N)
+	round.temp.theta = make([]*big.Int, count)
+	round.temp.sigma = make([]*big.Int, count)
+	for b := 0; b < count; b++ {
+		theta := modN.Mul(round.temp.k[b], round.temp.gamma[b])
+		sigma := modN.Mul(round.temp.k[b], round.temp.w)
+		for j := range round.Parties().IDs() {
+			if j == i {
+				continue
+			}
+			theta = modN.Add(theta, alphas[j][b].Add(alphas[j][b], round.temp.betas[j][b]))
+			sigma = modN.Add(sigma, us[j][b].Add(us[j][b], round.temp.vs[j][b]))
+		}
+		round.temp.theta[b] = theta
+		round.temp.sigma[b] = sigma
+	}
+
+	r3msg := NewPresignRound3Message(round.PartyID(), round.temp.theta)
+	round.temp.presignRound3Messages[i] = r3msg
+	round.out <- r3msg
+
+	return nil
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.presignRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		if len(msg.Content().(*PresignRound3Message).GetTheta()) != round.temp.count {
+			return false, round.WrapError(errors.New("wrong number of presignatures"), msg.GetFrom())
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &round4{round}
+}
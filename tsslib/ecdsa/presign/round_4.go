@@ -0,0 +1,177 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/crypto/commitments"
+	"CipherMachine/tsslib/crypto/schnorr"
+	"CipherMachine/tsslib/tss"
+)
+
+func (round *round4) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	count := round.temp.count
+	modN := common.ModInt(round.EC().Params().N)
+
+	round.temp.thetaInverse = make([]*big.Int, count)
+	piGammas := make([]*schnorr.ZKProof, count)
+	for b := 0; b < count; b++ {
+		thetaInverse := new(big.Int).Set(round.temp.theta[b])
+		for j := range round.Parties().IDs() {
+			if j == i {
+				continue
+			}
+			r3msg := round.temp.presignRound3Messages[j].Content().(*PresignRound3Message)
+			thetaInverse = modN.Add(thetaInverse, new(big.Int).SetBytes(r3msg.GetTheta()[b]))
+		}
+		// compute the multiplicative inverse thelta mod q
+		round.temp.thetaInverse[b] = modN.ModInverse(thetaInverse)
+
+		piGamma, err := schnorr.NewZKProof(round.temp.gamma[b], round.temp.pointGamma[b])
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "NewZKProof(gamma, bigGamma)"))
+		}
+		piGammas[b] = piGamma
+	}
+	r4msg := NewPresignRound4Message(round.PartyID(), round.temp.deCommit, piGammas)
+	round.temp.presignRound4Messages[i] = r4msg
+	round.out <- r4msg
+
+	return nil
+}
+
+func (round *round4) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.presignRound4Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		if msg.Content().(*PresignRound4Message).Count() != round.temp.count {
+			return false, round.WrapError(errors.New("wrong number of presignatures"), msg.GetFrom())
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound4Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round4) NextRound() tss.Round {
+	round.started = false
+	if round.sigEnd == nil {
+		return &presignFinalization{round}
+	}
+	return &round5{round}
+}
+
+// presignatures opens the gamma points of the other parties and makes the
+// presignatures of the session, R = (sum of the gamma points)^(1/theta).
+func (round *round4) presignatures() ([]Presignature, *tss.Error) {
+	count := round.temp.count
+	bigRs := make([]*crypto.ECPoint, count)
+	copy(bigRs, round.temp.pointGamma)
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		r1msg2 := round.temp.presignRound1Message2s[j].Content().(*PresignRound1Message2)
+		r4msg := round.temp.presignRound4Messages[j].Content().(*PresignRound4Message)
+		SCj, SDj := r1msg2.UnmarshalCommitment(), r4msg.UnmarshalDeCommitment()
+		cmtDeCmt := commitments.HashCommitDecommit{C: SCj, D: SDj}
+		ok, bigGammaJs := cmtDeCmt.DeCommit()
+		if !ok || len(bigGammaJs) != 2*count {
+			return nil, round.WrapError(errors.New("commitment verify failed"), Pj)
+		}
+		for b := 0; b < count; b++ {
+			bigGammaJPoint, err := crypto.NewECPoint(round.EC(), bigGammaJs[2*b], bigGammaJs[2*b+1])
+			if err != nil {
+				return nil, round.WrapError(errors2.Wrapf(err, "NewECPoint(bigGammaJ)"), Pj)
+			}
+			proof, err := r4msg.UnmarshalZKProof(round.EC(), b)
+			if err != nil {
+				return nil, round.WrapError(errors.New("failed to unmarshal bigGamma proof"), Pj)
+			}
+			if !proof.Verify(bigGammaJPoint) {
+				return nil, round.WrapError(errors.New("failed to prove bigGamma"), Pj)
+			}
+			if bigRs[b], err = bigRs[b].Add(bigGammaJPoint); err != nil {
+				return nil, round.WrapError(errors2.Wrapf(err, "R.Add(bigGammaJ)"), Pj)
+			}
+		}
+	}
+
+	presigs := make([]Presignature, count)
+	for b := 0; b < count; b++ {
+		presigs[b] = Presignature{
+			K:        round.temp.k[b],
+			Sigma:    round.temp.sigma[b],
+			R:        bigRs[b].ScalarMult(round.temp.thetaInverse[b]),
+			ECDSAPub: round.key.ECDSAPub,
+			Ks:       round.key.Ks,
+		}
+		// clear temp.w, temp.k and temp.gamma from memory, lint ignore
+		round.temp.k[b] = zero
+		round.temp.gamma[b] = zero
+	}
+	round.temp.w = zero
+	return presigs, nil
+}
+
+func (round *presignFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	presigs, err := round.presignatures()
+	if err != nil {
+		return err
+	}
+	for j := range round.ok {
+		round.ok[j] = true
+	}
+	round.end <- presigs
+
+	return nil
+}
+
+func (round *presignFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *presignFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *presignFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
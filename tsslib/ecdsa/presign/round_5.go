@@ -0,0 +1,118 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/tss"
+)
+
+// round 5 signs every message with its presignature, s_i = m*k_i + r*sigma_i.
+// The child key at tweak has the secret x+tweak, so its sigma_i takes
+// tweak*k_i more.
+func (round *round5) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	N := round.EC().Params().N
+	for b, m := range round.temp.msgs {
+		if m.Cmp(N) >= 0 {
+			return round.WrapError(fmt.Errorf("hashed message %d is not valid", b))
+		}
+	}
+	presigs := round.temp.presigs
+	if presigs == nil {
+		var err *tss.Error
+		if presigs, err = round.presignatures(); err != nil {
+			return err
+		}
+	}
+	for b, presig := range presigs {
+		if err := round.checkPresignature(presig); err != nil {
+			return round.WrapError(fmt.Errorf("presignature %d: %v", b, err))
+		}
+	}
+
+	modN := common.ModInt(N)
+	round.temp.bigR = make([]*crypto.ECPoint, len(presigs))
+	round.temp.si = make([]*big.Int, len(presigs))
+	for b, presig := range presigs {
+		r := new(big.Int).Mod(presig.R.X(), N)
+		sigma := presig.Sigma
+		if round.temp.tweak != nil {
+			sigma = modN.Add(sigma, modN.Mul(round.temp.tweak, presig.K))
+		}
+		round.temp.bigR[b] = presig.R
+		round.temp.si[b] = modN.Add(modN.Mul(round.temp.msgs[b], presig.K), modN.Mul(r, sigma))
+	}
+	round.temp.presigs = nil
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+	r5msg := NewPresignRound5Message(round.PartyID(), round.temp.si)
+	round.temp.presignRound5Messages[i] = r5msg
+	round.out <- r5msg
+
+	return nil
+}
+
+// checkPresignature returns an error if presig was not made for the key and
+// the parties of the round.
+func (round *round5) checkPresignature(presig Presignature) error {
+	if presig.K == nil || presig.Sigma == nil || presig.R == nil {
+		return errors.New("incomplete presignature")
+	}
+	if presig.ECDSAPub == nil || !presig.ECDSAPub.Equals(round.key.ECDSAPub) {
+		return errors.New("presignature is of another key")
+	}
+	if len(presig.Ks) != len(round.key.Ks) {
+		return errors.New("presignature is of other parties")
+	}
+	for j, k := range presig.Ks {
+		if k.Cmp(round.key.Ks[j]) != 0 {
+			return errors.New("presignature is of other parties")
+		}
+	}
+	return nil
+}
+
+func (round *round5) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.presignRound5Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		if len(msg.Content().(*PresignRound5Message).GetS()) != round.temp.count {
+			return false, round.WrapError(errors.New("wrong number of signature shares"), msg.GetFrom())
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round5) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound5Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round5) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -37,8 +37,8 @@ func (round *round9) Start() *tss.Error {
 			return round.WrapError(errors.New("de-commitment for bigVj and bigAj failed"), Pj)
 		}
 		UjX, UjY, TjX, TjY := values[0], values[1], values[2], values[3]
-		UX, UY = tss.EC().Add(UX, UY, UjX, UjY)
-		TX, TY = tss.EC().Add(TX, TY, TjX, TjY)
+		UX, UY = round.EC().Add(UX, UY, UjX, UjY)
+		TX, TY = round.EC().Add(TX, TY, TjX, TjY)
 	}
 	if UX.Cmp(TX) != 0 || UY.Cmp(TY) != 0 {
 		return round.WrapError(errors.New("U doesn't equal T"), round.PartyID())
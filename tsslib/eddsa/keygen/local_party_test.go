@@ -0,0 +1,92 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/elliptic"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/crypto/vss"
+	"CipherMachine/tsslib/tss"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// FROST keys are made by this keygen on secp256k1
+	for _, ec := range []elliptic.Curve{tss.Edwards(), tss.S256()} {
+		testE2E(t, ec)
+	}
+}
+
+func testE2E(t *testing.T, ec elliptic.Curve) {
+	participants, threshold := 4, 2
+	pIDs := tss.GenerateTestPartyIDs(participants)
+	keys, err := GenerateTestKeysOnCurve(ec, pIDs, threshold)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	pub := keys[0].EDDSAPub
+	for j, key := range keys {
+		assert.True(t, key.EDDSAPub.Equals(pub), "every party should have the same public key")
+		assert.Equal(t, ec, key.EDDSAPub.Curve(), "the public key should be on the curve of the keygen")
+		// xj tests: BigXj == xj*G
+		for _, other := range keys {
+			assert.True(t, other.BigXj[j].Equals(crypto.ScalarBaseMult(ec, key.Xi)), "ensure BigX_j == g^x_j")
+		}
+	}
+
+	// any threshold+1 of the shares make the secret of the public key
+	shares := make(vss.Shares, 0, len(keys))
+	for _, key := range keys {
+		shares = append(shares, &vss.Share{Threshold: threshold, ID: key.ShareID, Share: key.Xi})
+	}
+	for _, set := range []vss.Shares{shares[:threshold+1], shares[1:]} {
+		x, err := set.ReConstruct(ec)
+		assert.NoError(t, err)
+		assert.True(t, crypto.ScalarBaseMult(ec, x).Equals(pub), "ensure x*G == y")
+	}
+	// fails if threshold cannot be satisfied
+	x, err := shares[:threshold].ReConstruct(ec)
+	assert.NoError(t, err)
+	assert.False(t, crypto.ScalarBaseMult(ec, x).Equals(pub), "ensure threshold shares do not make the secret")
+}
+
+func TestSaveDataJSON(t *testing.T) {
+	keys, err := GenerateTestKeys(tss.GenerateTestPartyIDs(2), 1)
+	if !assert.NoError(t, err) {
+		return
+	}
+	bz, err := json.Marshal(keys[1])
+	assert.NoError(t, err)
+	var save LocalPartySaveData
+	assert.NoError(t, json.Unmarshal(bz, &save))
+	assert.Equal(t, keys[1].Xi, save.Xi)
+	assert.Equal(t, keys[1].ShareID, save.ShareID)
+	assert.Equal(t, keys[1].Ks, save.Ks)
+	assert.True(t, save.EDDSAPub.Equals(keys[1].EDDSAPub))
+	assert.True(t, save.EDDSAPub.IsOnCurve())
+	assert.True(t, save.BigXj[0].Equals(keys[1].BigXj[0]))
+	assert.Equal(t, keys[1].EDDSAPub.SerializeEd25519(), save.EDDSAPub.SerializeEd25519())
+
+	// a point off the curve is refused
+	bz, _ = json.Marshal(map[string]interface{}{"EDDSAPub": map[string][]*big.Int{"Coords": {big.NewInt(1), big.NewInt(2)}}})
+	assert.Error(t, json.Unmarshal(bz, &save))
+}
@@ -0,0 +1,80 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/elliptic"
+	"errors"
+
+	"CipherMachine/tsslib/test"
+	"CipherMachine/tsslib/tss"
+)
+
+const (
+	TestParticipants = test.TestParticipants
+	TestThreshold    = test.TestThreshold
+)
+
+// GenerateTestKeys runs a keygen between pIDs in this process and returns the save data of each party in the order of pIDs.
+// The EdDSA keygen needs no safe primes, so the signing and resharing tests make their keys with it instead of fixture files.
+func GenerateTestKeys(pIDs tss.SortedPartyIDs, threshold int) ([]LocalPartySaveData, error) {
+	return GenerateTestKeysOnCurve(tss.Edwards(), pIDs, threshold)
+}
+
+// GenerateTestKeysOnCurve is GenerateTestKeys with the keygen run on ec.
+func GenerateTestKeysOnCurve(ec elliptic.Curve, pIDs tss.SortedPartyIDs, threshold int) ([]LocalPartySaveData, error) {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan LocalPartySaveData, len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(ec, p2pCtx, pIDs[i], len(pIDs), threshold)
+		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	keys := make([]LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			return nil, err
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil { // broadcast!
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			} else { // point-to-point!
+				if dest[0].Index == msg.GetFrom().Index {
+					return nil, errors.New("a party tried to send a message to itself")
+				}
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			if err != nil {
+				return nil, err
+			}
+			keys[index] = save
+			ended++
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,178 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/eddsa/keygen"
+	"CipherMachine/tsslib/eddsa/signing"
+	"CipherMachine/tsslib/test"
+	"CipherMachine/tsslib/tss"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	threshold, newThreshold := 1, 2
+
+	// PHASE: keygen, the last t+1 parties make the old committee
+	allPIDs := tss.GenerateTestPartyIDs(3)
+	allKeys, err := keygen.GenerateTestKeys(allPIDs, threshold)
+	if !assert.NoError(t, err) {
+		return
+	}
+	oldKeys := allKeys[1:]
+	oldPIDs := tss.SortPartyIDs(tss.UnSortedPartyIDs{allPIDs[1], allPIDs[2]})
+
+	// PHASE: resharing
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	newPIDs := tss.GenerateTestPartyIDs(4)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+	newPCount := len(newPIDs)
+
+	oldCommittee := make([]*LocalParty, 0, len(oldPIDs))
+	newCommittee := make([]*LocalParty, 0, newPCount)
+	bothCommitteesPax := len(oldPIDs) + newPCount
+
+	errCh := make(chan *tss.Error, bothCommitteesPax)
+	outCh := make(chan tss.Message, bothCommitteesPax)
+	endCh := make(chan keygen.LocalPartySaveData, bothCommitteesPax)
+
+	updater := test.SharedPartyUpdater
+
+	// init the old parties first
+	for j, pID := range oldPIDs {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, len(allPIDs), threshold, newPCount, newThreshold)
+		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty) // discard old key data
+		oldCommittee = append(oldCommittee, P)
+	}
+	// init the new parties
+	for _, pID := range newPIDs {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, len(allPIDs), threshold, newPCount, newThreshold)
+		save := keygen.NewLocalPartySaveData(newPCount)
+		P := NewLocalParty(params, save, outCh, endCh).(*LocalParty)
+		newCommittee = append(newCommittee, P)
+	}
+
+	// start the new parties; they will wait for messages
+	for _, P := range newCommittee {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	// start the old parties; they will send messages
+	for _, P := range oldCommittee {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(newCommittee))
+	endedOldCommittee, reSharingEnded := 0, 0
+	for reSharingEnded < bothCommitteesPax {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				t.Fatal("did not expect a msg to have a nil destination during resharing")
+			}
+			if msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest[:len(oldCommittee)] {
+					go updater(oldCommittee[destP.Index], msg, errCh)
+				}
+			}
+			if !msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest {
+					go updater(newCommittee[destP.Index], msg, errCh)
+				}
+			}
+
+		case save := <-endCh:
+			// old committee members that aren't receiving a share have their Xi zeroed
+			if save.Xi != nil {
+				index, err := save.OriginalIndex()
+				assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+				newKeys[index] = save
+			} else {
+				endedOldCommittee++
+			}
+			reSharingEnded++
+		}
+	}
+	assert.Equal(t, len(oldCommittee), endedOldCommittee)
+	for j, key := range newKeys {
+		assert.True(t, key.EDDSAPub.Equals(allKeys[0].EDDSAPub), "the public key is kept")
+		// xj test: BigXj == xj*G
+		gXj := crypto.ScalarBaseMult(tss.Edwards(), key.Xi)
+		assert.True(t, key.BigXj[j].Equals(gXj), "ensure BigX_j == g^x_j")
+	}
+
+	// PHASE: signing with newThreshold+1 of the new committee
+	signKeys, signPIDs := newKeys[1:], tss.SortPartyIDs(tss.UnSortedPartyIDs(newPIDs[1:]))
+	signP2pCtx := tss.NewPeerContext(signPIDs)
+	signParties := make([]*signing.LocalParty, 0, len(signPIDs))
+
+	signErrCh := make(chan *tss.Error, len(signPIDs))
+	signOutCh := make(chan tss.Message, len(signPIDs))
+	signEndCh := make(chan common.SignatureData, len(signPIDs))
+
+	msg := []byte("resharing")
+	for j, signPID := range signPIDs {
+		params := tss.NewParameters(tss.Edwards(), signP2pCtx, signPID, len(signPIDs), newThreshold)
+		P := signing.NewLocalParty(msg, params, signKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
+		signParties = append(signParties, P)
+		go func(P *signing.LocalParty) {
+			if err := P.Start(); err != nil {
+				signErrCh <- err
+			}
+		}(P)
+	}
+
+	for signEnded := 0; signEnded < len(signPIDs); {
+		select {
+		case err := <-signErrCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-signOutCh:
+			for _, P := range signParties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go updater(P, msg, signErrCh)
+			}
+
+		case sig := <-signEndCh:
+			pk := ed25519.PublicKey(allKeys[0].EDDSAPub.SerializeEd25519())
+			assert.True(t, ed25519.Verify(pk, msg, sig.Signature), "ed25519 verify must pass")
+			signEnded++
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing
+
+import (
+	"errors"
+
+	"CipherMachine/tsslib/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK() // resets both round.oldOK and round.newOK
+	round.allOldOK()
+
+	// only the old committee waits for the "ACK" messages
+	if !round.ReSharingParams().IsOldCommittee() {
+		round.allNewOK()
+	}
+
+	if !round.ReSharingParams().IsNewCommittee() {
+		return nil
+	}
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 1. "broadcast" "ACK" members of the OLD committee
+	r2msg := NewDGRound2Message(
+		round.OldParties().IDs().Exclude(round.PartyID()), round.PartyID())
+	round.temp.dgRound2Messages[i] = r2msg
+	round.out <- r2msg
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if round.ReSharingParams().IsOldCommittee() {
+		if _, ok := msg.Content().(*DGRound2Message); ok {
+			return msg.IsBroadcast()
+		}
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	if round.ReSharingParams().IsOldCommittee() {
+		// accept messages from new -> old committee
+		for j, msg := range round.temp.dgRound2Messages {
+			if round.newOK[j] {
+				continue
+			}
+			if msg == nil || !round.CanAccept(msg) {
+				return false, nil
+			}
+			round.newOK[j] = true
+		}
+	} else if !round.ReSharingParams().IsNewCommittee() {
+		return false, round.WrapError(errors.New("this party is not in the old or the new committee"), round.PartyID())
+	}
+	return true, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
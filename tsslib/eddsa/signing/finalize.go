@@ -0,0 +1,68 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	sumS := round.temp.si
+	modN := common.ModInt(tss.Edwards().Params().N)
+
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		sumS = modN.Add(sumS, r3msg.UnmarshalS())
+	}
+
+	// save the signature for final output, R || S of RFC 8032
+	s := encodeScalar(sumS)
+	round.data.Signature = append(append([]byte{}, round.temp.r...), s...)
+	round.data.R = round.temp.r
+	round.data.S = s
+	round.data.M = round.temp.m
+
+	pk := ed25519.PublicKey(round.key.EDDSAPub.SerializeEd25519())
+	ok := ed25519.Verify(pk, round.temp.m, round.data.Signature)
+	if !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed"))
+	}
+
+	round.end <- *round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
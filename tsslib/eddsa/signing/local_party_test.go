@@ -0,0 +1,103 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ed25519"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/eddsa/keygen"
+	"CipherMachine/tsslib/test"
+	"CipherMachine/tsslib/tss"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	threshold := 1
+	pIDs := tss.GenerateTestPartyIDs(3)
+	allKeys, err := keygen.GenerateTestKeys(pIDs, threshold)
+	if !assert.NoError(t, err) {
+		return
+	}
+	// sign with the first and the last of the parties
+	keys := []keygen.LocalPartySaveData{allKeys[0], allKeys[2]}
+	signPIDs := tss.SortPartyIDs(tss.UnSortedPartyIDs{pIDs[0], pIDs[2]})
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	msg := []byte("a message of any length, ed25519 hashes it itself")
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+
+		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case sig := <-endCh:
+			// every party verified the signature before it ended, check it again with the standard library
+			pk := ed25519.PublicKey(keys[0].EDDSAPub.SerializeEd25519())
+			assert.Len(t, sig.Signature, ed25519.SignatureSize)
+			assert.True(t, ed25519.Verify(pk, msg, sig.Signature), "ed25519 verify must pass")
+			assert.Equal(t, msg, sig.M)
+			if atomic.AddInt32(&ended, 1) == int32(len(signPIDs)) {
+				t.Logf("Done. Received signature data from %d participants", ended)
+				break signing
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha512"
+	"math/big"
+
+	"CipherMachine/tsslib/tss"
+)
+
+// reverse returns a copy of bz in the reverse order, Ed25519 scalars are little-endian
+func reverse(bz []byte) []byte {
+	out := make([]byte, len(bz))
+	for i, b := range bz {
+		out[len(bz)-1-i] = b
+	}
+	return out
+}
+
+// encodeScalar returns the 32 byte little-endian encoding of s
+func encodeScalar(s *big.Int) []byte {
+	out := make([]byte, 32)
+	copy(out, reverse(s.Bytes()))
+	return out
+}
+
+// challenge returns SHA512(R || A || M) reduced mod L, the h of RFC 8032
+func challenge(encodedR, encodedA, msg []byte) *big.Int {
+	h := sha512.New()
+	h.Write(encodedR)
+	h.Write(encodedA)
+	h.Write(msg)
+	k := new(big.Int).SetBytes(reverse(h.Sum(nil)))
+	return k.Mod(k, tss.Edwards().Params().N)
+}
@@ -0,0 +1,28 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+
+	eddsakeygen "CipherMachine/tsslib/eddsa/keygen"
+	"CipherMachine/tsslib/tss"
+)
+
+// NewLocalParty returns a party of the keygen of a FROST key. The shares of a
+// FROST key are made as the ones of an EdDSA key, so this is the EdDSA keygen
+// run on secp256k1. The save data sent to end is read with NewSaveData.
+func NewLocalParty(
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- eddsakeygen.LocalPartySaveData,
+) tss.Party {
+	if params.EC() != tss.S256() {
+		panic(errors.New("frost keygen: the parameters are not on secp256k1"))
+	}
+	return eddsakeygen.NewLocalParty(params, out, end)
+}
@@ -0,0 +1,44 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"CipherMachine/tsslib/tss"
+)
+
+func TestSaveDataJSON(t *testing.T) {
+	keys, err := GenerateTestKeys(tss.GenerateTestPartyIDs(2), 1)
+	if !assert.NoError(t, err) {
+		return
+	}
+	bz, err := json.Marshal(keys[1])
+	assert.NoError(t, err)
+	var save LocalPartySaveData
+	assert.NoError(t, json.Unmarshal(bz, &save))
+	assert.Equal(t, keys[1].Xi, save.Xi)
+	assert.Equal(t, keys[1].ShareID, save.ShareID)
+	assert.Equal(t, keys[1].Ks, save.Ks)
+	assert.True(t, save.SchnorrPub.Equals(keys[1].SchnorrPub))
+	assert.True(t, save.SchnorrPub.IsOnCurve())
+	assert.True(t, save.BigXj[0].Equals(keys[1].BigXj[0]))
+	assert.Equal(t, keys[1].SchnorrPub.SerializeXOnly(), save.SchnorrPub.SerializeXOnly())
+
+	// the points saved before the json of an ECPoint named its curve are on secp256k1
+	bz, _ = json.Marshal(map[string]interface{}{"SchnorrPub": map[string][]*big.Int{"Coords": {keys[1].SchnorrPub.X(), keys[1].SchnorrPub.Y()}}})
+	assert.NoError(t, json.Unmarshal(bz, &save))
+	assert.Equal(t, tss.S256(), save.SchnorrPub.Curve())
+
+	// a point off the curve is refused
+	bz, _ = json.Marshal(map[string]interface{}{"SchnorrPub": map[string][]*big.Int{"Coords": {big.NewInt(1), big.NewInt(2)}}})
+	assert.Error(t, json.Unmarshal(bz, &save))
+}
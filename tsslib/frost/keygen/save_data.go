@@ -0,0 +1,112 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	eddsakeygen "CipherMachine/tsslib/eddsa/keygen"
+	"CipherMachine/tsslib/tss"
+)
+
+type (
+	LocalSecrets = eddsakeygen.LocalSecrets
+
+	// Everything in LocalPartySaveData is saved locally to user's HD when done
+	LocalPartySaveData struct {
+		LocalSecrets
+
+		// original indexes (ki in signing preparation phase)
+		Ks []*big.Int
+
+		// public keys (Xj = uj*G for each Pj)
+		BigXj []*crypto.ECPoint // Xj
+
+		// the Schnorr public key, its Y may be odd
+		SchnorrPub *crypto.ECPoint // y
+	}
+)
+
+// NewSaveData returns the save data of the FROST key made by the keygen that ended with save.
+func NewSaveData(save eddsakeygen.LocalPartySaveData) LocalPartySaveData {
+	return LocalPartySaveData{
+		LocalSecrets: save.LocalSecrets,
+		Ks:           save.Ks,
+		BigXj:        save.BigXj,
+		SchnorrPub:   save.EDDSAPub,
+	}
+}
+
+func NewLocalPartySaveData(partyCount int) (saveData LocalPartySaveData) {
+	saveData.Ks = make([]*big.Int, partyCount)
+	saveData.BigXj = make([]*crypto.ECPoint, partyCount)
+	return
+}
+
+// UnmarshalJSON reads the points back on secp256k1, also the ones saved before the json of an ECPoint named its curve.
+func (save *LocalPartySaveData) UnmarshalJSON(bz []byte) error {
+	type point struct {
+		Coords [2]*big.Int
+	}
+	var aux struct {
+		LocalSecrets
+		Ks         []*big.Int
+		BigXj      []*point
+		SchnorrPub *point
+	}
+	if err := json.Unmarshal(bz, &aux); err != nil {
+		return err
+	}
+	toECPoint := func(p *point) (*crypto.ECPoint, error) {
+		if p == nil {
+			return nil, nil
+		}
+		return crypto.NewECPoint(tss.S256(), p.Coords[0], p.Coords[1])
+	}
+	bigXj := make([]*crypto.ECPoint, len(aux.BigXj))
+	for j, p := range aux.BigXj {
+		var err error
+		if bigXj[j], err = toECPoint(p); err != nil {
+			return err
+		}
+	}
+	pub, err := toECPoint(aux.SchnorrPub)
+	if err != nil {
+		return err
+	}
+	*save = LocalPartySaveData{
+		LocalSecrets: aux.LocalSecrets,
+		Ks:           aux.Ks,
+		BigXj:        bigXj,
+		SchnorrPub:   pub,
+	}
+	return nil
+}
+
+// BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
+func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) LocalPartySaveData {
+	keysToIndices := make(map[string]int, len(sourceData.Ks))
+	for j, kj := range sourceData.Ks {
+		keysToIndices[hex.EncodeToString(kj.Bytes())] = j
+	}
+	newData := NewLocalPartySaveData(sortedIDs.Len())
+	newData.LocalSecrets = sourceData.LocalSecrets
+	newData.SchnorrPub = sourceData.SchnorrPub
+	for j, id := range sortedIDs {
+		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
+		if !ok {
+			common.Logger.Warning("BuildLocalSaveDataSubset: unable to find a signer party in the local save data", id)
+		}
+		newData.Ks[j] = sourceData.Ks[savedIdx]
+		newData.BigXj[j] = sourceData.BigXj[savedIdx]
+	}
+	return newData
+}
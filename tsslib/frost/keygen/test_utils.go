@@ -0,0 +1,25 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	eddsakeygen "CipherMachine/tsslib/eddsa/keygen"
+	"CipherMachine/tsslib/tss"
+)
+
+// GenerateTestKeys runs a keygen between pIDs in this process and returns the save data of each party in the order of pIDs.
+func GenerateTestKeys(pIDs tss.SortedPartyIDs, threshold int) ([]LocalPartySaveData, error) {
+	saves, err := eddsakeygen.GenerateTestKeysOnCurve(tss.S256(), pIDs, threshold)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]LocalPartySaveData, len(saves))
+	for i, save := range saves {
+		keys[i] = NewSaveData(save)
+	}
+	return keys, nil
+}
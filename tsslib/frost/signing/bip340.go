@@ -0,0 +1,134 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/frost/keygen"
+	"CipherMachine/tsslib/tss"
+)
+
+// taggedHash returns the BIP340 tagged hash SHA256(SHA256(tag) || SHA256(tag) || data...)
+func taggedHash(tag string, data ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, bz := range data {
+		h.Write(bz)
+	}
+	return h.Sum(nil)
+}
+
+// challenge returns the BIP340 challenge e of the nonce point and public key X coordinates
+func challenge(r, pub, msg []byte) *big.Int {
+	e := new(big.Int).SetBytes(taggedHash("BIP0340/challenge", r, pub, msg))
+	return e.Mod(e, tss.S256().Params().N)
+}
+
+// liftX returns the point with X x and an even Y, nil if there is none
+func liftX(x *big.Int) *crypto.ECPoint {
+	params := tss.S256().Params()
+	if x.Cmp(params.P) >= 0 {
+		return nil
+	}
+	// y^2 = x^3 + 7, p = 3 mod 4 so y = (y^2)^((p+1)/4)
+	c := new(big.Int).Exp(x, big.NewInt(3), params.P)
+	c.Add(c, big.NewInt(7))
+	c.Mod(c, params.P)
+	e := new(big.Int).Add(params.P, big.NewInt(1))
+	y := new(big.Int).Exp(c, e.Rsh(e, 2), params.P)
+	if new(big.Int).Exp(y, big.NewInt(2), params.P).Cmp(c) != 0 {
+		return nil
+	}
+	if y.Bit(0) == 1 {
+		y.Sub(params.P, y)
+	}
+	return crypto.NewECPointNoCurveCheck(tss.S256(), x, y)
+}
+
+// Verify checks the 64 byte BIP340 signature sig of msg by the 32 byte X only public key pub.
+func Verify(pub, msg, sig []byte) bool {
+	params := tss.S256().Params()
+	if len(pub) != 32 || len(sig) != 64 {
+		return false
+	}
+	P := liftX(new(big.Int).SetBytes(pub))
+	if P == nil {
+		return false
+	}
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	if r.Cmp(params.P) >= 0 || s.Cmp(params.N) >= 0 {
+		return false
+	}
+	// R = s*G - e*P
+	e := challenge(sig[:32], pub, msg)
+	sGx, sGy := tss.S256().ScalarBaseMult(s.Bytes())
+	ePx, ePy := tss.S256().ScalarMult(P.X(), P.Y(), new(big.Int).Sub(params.N, e).Bytes())
+	Rx, Ry := tss.S256().Add(sGx, sGy, ePx, ePy)
+	if Rx.Sign() == 0 && Ry.Sign() == 0 {
+		// the point at infinity
+		return false
+	}
+	return Ry.Bit(0) == 0 && Rx.Cmp(r) == 0
+}
+
+// TaprootOutputKey returns the BIP341 output key of the internal key pub committing to merkleRoot, an empty merkleRoot commits to no scripts, and the tweak t of Q = lift_x(P) + t*G.
+func TaprootOutputKey(pub *crypto.ECPoint, merkleRoot []byte) (*crypto.ECPoint, *big.Int, error) {
+	if len(merkleRoot) != 0 && len(merkleRoot) != 32 {
+		return nil, nil, errors.New("the merkle root must be empty or 32 bytes")
+	}
+	t := new(big.Int).SetBytes(taggedHash("TapTweak", pub.SerializeXOnly(), merkleRoot))
+	if t.Cmp(tss.S256().Params().N) >= 0 {
+		return nil, nil, errors.New("the taproot tweak is not a scalar")
+	}
+	P := pub
+	if P.Y().Bit(0) == 1 {
+		P = negate(P)
+	}
+	Q, err := P.Add(crypto.ScalarBaseMult(tss.S256(), t))
+	if err != nil {
+		return nil, nil, err
+	}
+	return Q, t, nil
+}
+
+// TaprootKey returns the shares of the BIP341 output key of key committing to merkleRoot, signing with them spends the taproot output by its key path.
+// An internal key with an odd Y negates the secret first, as BIP341 takes its even Y lift, so every share Xi and public share BigXj are negated and then moved by t.
+func TaprootKey(key keygen.LocalPartySaveData, merkleRoot []byte) (keygen.LocalPartySaveData, error) {
+	if key.SchnorrPub == nil || key.Xi == nil {
+		return key, errors.New("incomplete share")
+	}
+	Q, t, err := TaprootOutputKey(key.SchnorrPub, merkleRoot)
+	if err != nil {
+		return key, err
+	}
+	N := tss.S256().Params().N
+	neg := key.SchnorrPub.Y().Bit(0) == 1
+	xi := new(big.Int).Set(key.Xi)
+	if neg {
+		xi.Sub(N, xi)
+	}
+	tG := crypto.ScalarBaseMult(tss.S256(), t)
+	bigXj := make([]*crypto.ECPoint, len(key.BigXj))
+	for j, Xj := range key.BigXj {
+		if neg {
+			Xj = negate(Xj)
+		}
+		if bigXj[j], err = Xj.Add(tG); err != nil {
+			return key, err
+		}
+	}
+	key.Xi = xi.Mod(xi.Add(xi, t), N)
+	key.BigXj = bigXj
+	key.SchnorrPub = Q
+	return key, nil
+}
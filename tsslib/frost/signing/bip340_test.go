@@ -0,0 +1,35 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// test vectors of BIP340
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		pub, msg, sig string
+		valid         bool
+	}{
+		{
+			"F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+			true,
+		},
+	}
+	for i, tt := range tests {
+		pub, _ := hex.DecodeString(tt.pub)
+		msg, _ := hex.DecodeString(tt.msg)
+		sig, _ := hex.DecodeString(tt.sig)
+		assert.Equal(t, tt.valid, Verify(pub, msg, sig), "vector %d", i)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	sumZ := round.temp.zi
+	modN := common.ModInt(tss.S256().Params().N)
+
+	// 1. check zj*G == Rj + c*Yj, a bad partial signature names its signer
+	culprits := make([]*tss.PartyID, 0)
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		zj := round.temp.signRound2Messages[j].Content().(*SignRound2Message).UnmarshalZ()
+		want, err := round.temp.bigRj[j].Add(round.temp.bigYj[j].ScalarMult(round.temp.c))
+		if err != nil || zj.Cmp(tss.S256().Params().N) >= 0 || !crypto.ScalarBaseMult(tss.S256(), zj).Equals(want) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sumZ = modN.Add(sumZ, zj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("partial signature verify failed"), culprits...)
+	}
+
+	// 2. save the signature for final output, x(R) || z of BIP340
+	r := round.temp.r.SerializeXOnly()
+	z := make([]byte, 32)
+	sumZ.FillBytes(z)
+	round.data.Signature = append(append([]byte{}, r...), z...)
+	round.data.R = r
+	round.data.S = z
+	round.data.M = round.temp.m
+
+	if !Verify(round.key.SchnorrPub.SerializeXOnly(), round.temp.m, round.data.Signature) {
+		return round.WrapError(fmt.Errorf("signature verification failed"))
+	}
+
+	round.end <- *round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
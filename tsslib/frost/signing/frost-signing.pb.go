@@ -0,0 +1,147 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: protob/frost-signing.proto
+
+package signing
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Represents a BROADCAST message sent to all parties during Round 1 of the FROST signing protocol.
+type SignRound1Message struct {
+	DX                   []byte   `protobuf:"bytes,1,opt,name=d_x,json=dX,proto3" json:"d_x,omitempty"`
+	DY                   []byte   `protobuf:"bytes,2,opt,name=d_y,json=dY,proto3" json:"d_y,omitempty"`
+	EX                   []byte   `protobuf:"bytes,3,opt,name=e_x,json=eX,proto3" json:"e_x,omitempty"`
+	EY                   []byte   `protobuf:"bytes,4,opt,name=e_y,json=eY,proto3" json:"e_y,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignRound1Message) Reset()         { *m = SignRound1Message{} }
+func (m *SignRound1Message) String() string { return proto.CompactTextString(m) }
+func (*SignRound1Message) ProtoMessage()    {}
+func (*SignRound1Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f71f06fe0f2bcf10, []int{0}
+}
+
+func (m *SignRound1Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignRound1Message.Unmarshal(m, b)
+}
+func (m *SignRound1Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignRound1Message.Marshal(b, m, deterministic)
+}
+func (m *SignRound1Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignRound1Message.Merge(m, src)
+}
+func (m *SignRound1Message) XXX_Size() int {
+	return xxx_messageInfo_SignRound1Message.Size(m)
+}
+func (m *SignRound1Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignRound1Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignRound1Message proto.InternalMessageInfo
+
+func (m *SignRound1Message) GetDX() []byte {
+	if m != nil {
+		return m.DX
+	}
+	return nil
+}
+
+func (m *SignRound1Message) GetDY() []byte {
+	if m != nil {
+		return m.DY
+	}
+	return nil
+}
+
+func (m *SignRound1Message) GetEX() []byte {
+	if m != nil {
+		return m.EX
+	}
+	return nil
+}
+
+func (m *SignRound1Message) GetEY() []byte {
+	if m != nil {
+		return m.EY
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 2 of the FROST signing protocol.
+type SignRound2Message struct {
+	Z                    []byte   `protobuf:"bytes,1,opt,name=z,proto3" json:"z,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignRound2Message) Reset()         { *m = SignRound2Message{} }
+func (m *SignRound2Message) String() string { return proto.CompactTextString(m) }
+func (*SignRound2Message) ProtoMessage()    {}
+func (*SignRound2Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f71f06fe0f2bcf10, []int{1}
+}
+
+func (m *SignRound2Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignRound2Message.Unmarshal(m, b)
+}
+func (m *SignRound2Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignRound2Message.Marshal(b, m, deterministic)
+}
+func (m *SignRound2Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignRound2Message.Merge(m, src)
+}
+func (m *SignRound2Message) XXX_Size() int {
+	return xxx_messageInfo_SignRound2Message.Size(m)
+}
+func (m *SignRound2Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignRound2Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignRound2Message proto.InternalMessageInfo
+
+func (m *SignRound2Message) GetZ() []byte {
+	if m != nil {
+		return m.Z
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SignRound1Message)(nil), "binance.tsslib.frost.signing.SignRound1Message")
+	proto.RegisterType((*SignRound2Message)(nil), "binance.tsslib.frost.signing.SignRound2Message")
+}
+
+func init() { proto.RegisterFile("protob/frost-signing.proto", fileDescriptor_f71f06fe0f2bcf10) }
+
+var fileDescriptor_f71f06fe0f2bcf10 = []byte{
+	// 162 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x2a, 0x28, 0xca, 0x2f,
+	0xc9, 0x4f, 0xd2, 0x4f, 0x2b, 0xca, 0x2f, 0x2e, 0xd1, 0x2d, 0xce, 0x4c, 0xcf, 0xcb, 0xcc, 0x4b,
+	0xd7, 0x03, 0x0b, 0x0a, 0xc9, 0x24, 0x65, 0xe6, 0x25, 0xe6, 0x25, 0xa7, 0xea, 0x95, 0x14, 0x17,
+	0xe7, 0x64, 0x26, 0xe9, 0x81, 0xd5, 0xe8, 0x41, 0xd5, 0x28, 0x85, 0x73, 0x09, 0x06, 0x67, 0xa6,
+	0xe7, 0x05, 0xe5, 0x97, 0xe6, 0xa5, 0x18, 0xfa, 0xa6, 0x16, 0x17, 0x27, 0xa6, 0xa7, 0x0a, 0xf1,
+	0x73, 0x31, 0xa7, 0xc4, 0x57, 0x48, 0x30, 0x2a, 0x30, 0x6a, 0xf0, 0x04, 0x31, 0xa5, 0x44, 0x40,
+	0x04, 0x2a, 0x25, 0x98, 0xa0, 0x02, 0x91, 0x20, 0x81, 0xd4, 0xf8, 0x0a, 0x09, 0x66, 0x88, 0x40,
+	0x6a, 0x04, 0x44, 0xa0, 0x52, 0x82, 0x05, 0x2a, 0x10, 0xa9, 0xa4, 0x88, 0x64, 0xb0, 0x11, 0xcc,
+	0x60, 0x1e, 0x2e, 0xc6, 0x2a, 0xa8, 0xb1, 0x8c, 0x55, 0x4e, 0xfc, 0x51, 0xbc, 0x60, 0xc7, 0xe8,
+	0x43, 0x1d, 0x93, 0xc4, 0x06, 0x76, 0xb1, 0x31, 0x60, 0x00, 0xed, 0x7c, 0x3f, 0x5b, 0xcf, 0x00,
+	0x00, 0x00,
+}
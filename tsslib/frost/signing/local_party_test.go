@@ -0,0 +1,124 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/frost/keygen"
+	"CipherMachine/tsslib/test"
+	"CipherMachine/tsslib/tss"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	threshold := 2
+	// about half of the keys and nonce points have an odd Y, sign with a few keys to take every path
+	for k := 0; k < 4; k++ {
+		// sorting the signers re-indexes their party ids, so each keygen gets new ones
+		pIDs := tss.GenerateTestPartyIDs(4)
+		allKeys, err := keygen.GenerateTestKeys(pIDs, threshold)
+		if !assert.NoError(t, err) {
+			return
+		}
+		// sign with all but the second of the parties
+		keys := []keygen.LocalPartySaveData{allKeys[0], allKeys[2], allKeys[3]}
+		signPIDs := tss.SortPartyIDs(tss.UnSortedPartyIDs{pIDs[0], pIDs[2], pIDs[3]})
+
+		msg := sha256.Sum256([]byte("bip340 signs 32 byte messages"))
+		sig := testSign(t, signPIDs, threshold, keys, msg[:])
+		assert.Len(t, sig.Signature, 64)
+		assert.True(t, Verify(keys[0].SchnorrPub.SerializeXOnly(), msg[:], sig.Signature), "bip340 verify must pass")
+		assert.False(t, Verify(keys[0].SchnorrPub.SerializeXOnly(), msg[1:], sig.Signature))
+
+		// the key path spend of a taproot output
+		merkleRoot := sha256.Sum256([]byte("script tree"))
+		for _, root := range [][]byte{nil, merkleRoot[:]} {
+			tweaked := make([]keygen.LocalPartySaveData, len(keys))
+			for i, key := range keys {
+				tweaked[i], err = TaprootKey(key, root)
+				assert.NoError(t, err)
+			}
+			Q, _, err := TaprootOutputKey(keys[0].SchnorrPub, root)
+			assert.NoError(t, err)
+			assert.True(t, Q.Equals(tweaked[0].SchnorrPub))
+			sig = testSign(t, signPIDs, threshold, tweaked, msg[:])
+			assert.True(t, Verify(Q.SerializeXOnly(), msg[:], sig.Signature), "bip340 verify of the output key must pass")
+		}
+	}
+}
+
+func testSign(t *testing.T, signPIDs tss.SortedPartyIDs, threshold int, keys []keygen.LocalPartySaveData, msg []byte) common.SignatureData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+
+		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+	var sig common.SignatureData
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				t.Fatalf("party %d sent a p2p message, FROST signing only broadcasts", msg.GetFrom().Index)
+			}
+
+		case data := <-endCh:
+			// every party verified the signature before it ended, and they all made the same one
+			if sig.Signature != nil {
+				assert.Equal(t, sig.Signature, data.Signature)
+			}
+			sig = data
+			assert.Equal(t, msg, sig.M)
+			if atomic.AddInt32(&ended, 1) == int32(len(signPIDs)) {
+				return sig
+			}
+		}
+	}
+}
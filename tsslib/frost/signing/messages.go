@@ -0,0 +1,97 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into frost-signing.pb.go
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*SignRound1Message)(nil),
+		(*SignRound2Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewSignRound1Message(
+	from *tss.PartyID,
+	Di, Ei *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		DX: Di.X().Bytes(),
+		DY: Di.Y().Bytes(),
+		EX: Ei.X().Bytes(),
+		EY: Ei.Y().Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetDX()) &&
+		common.NonEmptyBytes(m.GetDY()) &&
+		common.NonEmptyBytes(m.GetEX()) &&
+		common.NonEmptyBytes(m.GetEY())
+}
+
+func (m *SignRound1Message) UnmarshalNonces() (*crypto.ECPoint, *crypto.ECPoint, error) {
+	Dj, err := crypto.NewECPoint(
+		tss.S256(),
+		new(big.Int).SetBytes(m.GetDX()),
+		new(big.Int).SetBytes(m.GetDY()))
+	if err != nil {
+		return nil, nil, err
+	}
+	Ej, err := crypto.NewECPoint(
+		tss.S256(),
+		new(big.Int).SetBytes(m.GetEX()),
+		new(big.Int).SetBytes(m.GetEY()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return Dj, Ej, nil
+}
+
+// ----- //
+
+func NewSignRound2Message(
+	from *tss.PartyID,
+	zi *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound2Message{
+		Z: zi.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetZ())
+}
+
+func (m *SignRound2Message) UnmarshalZ() *big.Int {
+	return new(big.Int).SetBytes(m.GetZ())
+}
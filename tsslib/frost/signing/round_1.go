@@ -0,0 +1,97 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/frost/keygen"
+	"CipherMachine/tsslib/tss"
+)
+
+// round 1 represents round 1 of the FROST signing, each signer publishes the points of its hiding and binding nonces
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	// 1. select the nonces di, ei
+	di := common.GetRandomPositiveInt(tss.S256().Params().N)
+	ei := common.GetRandomPositiveInt(tss.S256().Params().N)
+
+	// 2. store r1 message pieces
+	round.temp.di = di
+	round.temp.ei = ei
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 3. broadcast Di, Ei
+	r1msg := NewSignRound1Message(round.PartyID(), crypto.ScalarBaseMult(tss.S256(), di), crypto.ScalarBaseMult(tss.S256(), ei))
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to compute the Lagrange coefficients of the signers, every partial signature is checked against its signer's
+func (round *round1) prepare() error {
+	ks := round.key.Ks
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	lambdas := make([]*big.Int, len(ks))
+	for j := range ks {
+		lambda, err := lagrange(j, ks)
+		if err != nil {
+			return err
+		}
+		lambdas[j] = lambda
+	}
+	round.temp.lambdas = lambdas
+	return nil
+}
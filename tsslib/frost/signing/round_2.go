@@ -0,0 +1,121 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+	Ps := round.Parties().IDs()
+	modN := common.ModInt(tss.S256().Params().N)
+
+	// 1. read the nonce points of every signer
+	Dj, Ej := make([]*crypto.ECPoint, len(Ps)), make([]*crypto.ECPoint, len(Ps))
+	for j, Pj := range Ps {
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
+		var err error
+		if Dj[j], Ej[j], err = r1msg.UnmarshalNonces(); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "NewECPoint(Dj, Ej)"), Pj)
+		}
+	}
+
+	// 2-3. bind every nonce to the msg and the whole commitment list, then R = sum(Dj + rhoj*Ej)
+	pub := round.key.SchnorrPub
+	rhos := bindingFactors(pub, round.temp.m, round.key.Ks, Dj, Ej)
+	var R *crypto.ECPoint
+	for j, Pj := range Ps {
+		Rj, err := Dj[j].Add(Ej[j].ScalarMult(rhos[j]))
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "Dj.Add(rhoj*Ej)"), Pj)
+		}
+		round.temp.bigRj[j] = Rj
+		if R == nil {
+			R = Rj
+			continue
+		}
+		if R, err = R.Add(Rj); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "R.Add(Rj)"), Pj)
+		}
+	}
+
+	// 4. BIP340 takes R and the public key with an even Y, an odd one negates the nonces or the shares
+	ki := modN.Add(round.temp.di, modN.Mul(rhos[i], round.temp.ei))
+	wi := modN.Mul(round.temp.lambdas[i], round.key.Xi)
+	negR, negY := R.Y().Bit(0) == 1, pub.Y().Bit(0) == 1
+	if negR {
+		ki = modN.Sub(zero, ki)
+	}
+	if negY {
+		wi = modN.Sub(zero, wi)
+	}
+	for j := range Ps {
+		if negR {
+			round.temp.bigRj[j] = negate(round.temp.bigRj[j])
+		}
+		Yj := round.key.BigXj[j].ScalarMult(round.temp.lambdas[j])
+		if negY {
+			Yj = negate(Yj)
+		}
+		round.temp.bigYj[j] = Yj
+	}
+
+	// 5. compute c and zi = ki + c*wi
+	round.temp.r = R
+	round.temp.c = challenge(R.SerializeXOnly(), pub.SerializeXOnly(), round.temp.m)
+	round.temp.zi = modN.Add(ki, modN.Mul(round.temp.c, wi))
+
+	// security: a nonce must never sign twice
+	round.temp.di, round.temp.ei = nil, nil
+
+	// 6. broadcast zi to other parties
+	r2msg := NewSignRound2Message(round.PartyID(), round.temp.zi)
+	round.temp.signRound2Messages[i] = r2msg
+	round.out <- r2msg
+
+	return nil
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,62 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"CipherMachine/tsslib/common"
+	"CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/tss"
+)
+
+var zero = big.NewInt(0)
+
+// negate returns -p
+func negate(p *crypto.ECPoint) *crypto.ECPoint {
+	y := new(big.Int).Sub(tss.S256().Params().P, p.Y())
+	return crypto.NewECPointNoCurveCheck(tss.S256(), p.X(), y)
+}
+
+// lagrange returns the Lagrange coefficient at 0 of the signer j of the share ids ks
+func lagrange(j int, ks []*big.Int) (*big.Int, error) {
+	modQ := common.ModInt(tss.S256().Params().N)
+	lambda := big.NewInt(1)
+	for m, km := range ks {
+		if m == j {
+			continue
+		}
+		if km.Cmp(ks[j]) == 0 {
+			return nil, errors.New("index of two parties are equal")
+		}
+		// big.Int Div is calculated as: a/b = a * modInv(b,q)
+		lambda = modQ.Mul(lambda, modQ.Mul(km, modQ.ModInverse(new(big.Int).Sub(km, ks[j]))))
+	}
+	return lambda, nil
+}
+
+// bindingFactors returns rhoj of every signer, a hash of its share id, the public key, the msg and the nonce points of all the signers.
+// A signer cannot pick its nonces after seeing the others'.
+func bindingFactors(pub *crypto.ECPoint, msg []byte, ks []*big.Int, Dj, Ej []*crypto.ECPoint) []*big.Int {
+	msgHash := sha256.Sum256(msg)
+	commitments := make([]byte, 0, len(ks)*(32+33+33))
+	for j, kj := range ks {
+		var bz [32]byte
+		commitments = append(commitments, kj.FillBytes(bz[:])...)
+		commitments = append(commitments, Dj[j].SerializeCompressed()...)
+		commitments = append(commitments, Ej[j].SerializeCompressed()...)
+	}
+	rhos := make([]*big.Int, len(ks))
+	for j, kj := range ks {
+		var bz [32]byte
+		rho := new(big.Int).SetBytes(taggedHash("FROST/rho", pub.SerializeCompressed(), msgHash[:], commitments, kj.FillBytes(bz[:])))
+		rhos[j] = rho.Mod(rho, tss.S256().Params().N)
+	}
+	return rhos
+}
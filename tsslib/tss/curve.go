@@ -11,23 +11,77 @@ import (
 	"errors"
 
 	s256k1 "github.com/btcsuite/btcd/btcec"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+type CurveName string
+
+const (
+	Secp256k1 CurveName = "secp256k1"
+	P256      CurveName = "p256"
+	Ed25519   CurveName = "ed25519"
 )
 
 var (
-	ec elliptic.Curve
+	ec       elliptic.Curve
+	registry map[CurveName]elliptic.Curve
 )
 
 // Init default curve (secp256k1)
 func init() {
 	ec = s256k1.S256()
+
+	registry = make(map[CurveName]elliptic.Curve)
+	registry[Secp256k1] = s256k1.S256()
+	registry[P256] = elliptic.P256()
+	registry[Ed25519] = edwards.Edwards()
+}
+
+// RegisterCurve adds curve to the curves a point or a key may be saved with, under name. Call it before any party starts
+func RegisterCurve(name CurveName, curve elliptic.Curve) {
+	registry[name] = curve
+}
+
+// GetCurveByName returns the curve registered under name
+func GetCurveByName(name CurveName) (elliptic.Curve, bool) {
+	curve, exist := registry[name]
+	return curve, exist
 }
 
-// EC returns the current elliptic curve in use. The default is secp256k1
+// GetCurveName returns the name curve is registered under
+func GetCurveName(curve elliptic.Curve) (CurveName, bool) {
+	for name, c := range registry {
+		if c == curve {
+			return name, true
+		}
+	}
+	// a curve may be a copy of the registered one, edwards.Edwards() makes a new one on each call
+	params := curve.Params()
+	for name, c := range registry {
+		p := c.Params()
+		if p.P.Cmp(params.P) == 0 && p.N.Cmp(params.N) == 0 && p.Gx.Cmp(params.Gx) == 0 && p.Gy.Cmp(params.Gy) == 0 {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// EC returns the default elliptic curve, the curve of the points and keys saved without one. The default is secp256k1
 func EC() elliptic.Curve {
 	return ec
 }
 
-// SetCurve sets the curve used by TSS. Must be called before Start. The default is secp256k1
+// S256 returns the secp256k1 curve used by the FROST protocols, BIP340 signatures are only defined on it
+func S256() elliptic.Curve {
+	return s256k1.S256()
+}
+
+// Edwards returns the Ed25519 curve used by the EdDSA protocols
+func Edwards() elliptic.Curve {
+	return registry[Ed25519]
+}
+
+// SetCurve sets the default curve. The curve of a party is the one of its Parameters. The default is secp256k1
 func SetCurve(curve elliptic.Curve) {
 	if curve == nil {
 		panic(errors.New("SetCurve received a nil curve"))
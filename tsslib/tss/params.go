@@ -7,12 +7,14 @@
 package tss
 
 import (
+	"crypto/elliptic"
 	"errors"
 	"time"
 )
 
 type (
 	Parameters struct {
+		ec                  elliptic.Curve
 		partyID             *PartyID
 		parties             *PeerContext
 		partyCount          int
@@ -33,7 +35,7 @@ const (
 )
 
 // Exported, used in `tss` client
-func NewParameters(ctx *PeerContext, partyID *PartyID, partyCount, threshold int, optionalSafePrimeGenTimeout ...time.Duration) *Parameters {
+func NewParameters(ec elliptic.Curve, ctx *PeerContext, partyID *PartyID, partyCount, threshold int, optionalSafePrimeGenTimeout ...time.Duration) *Parameters {
 	var safePrimeGenTimeout time.Duration
 	if 0 < len(optionalSafePrimeGenTimeout) {
 		if 1 < len(optionalSafePrimeGenTimeout) {
@@ -43,7 +45,11 @@ func NewParameters(ctx *PeerContext, partyID *PartyID, partyCount, threshold int
 	} else {
 		safePrimeGenTimeout = defaultSafePrimeGenTimeout
 	}
+	if ec == nil {
+		panic(errors.New("NewParameters received a nil curve"))
+	}
 	return &Parameters{
+		ec:                  ec,
 		parties:             ctx,
 		partyID:             partyID,
 		partyCount:          partyCount,
@@ -52,6 +58,11 @@ func NewParameters(ctx *PeerContext, partyID *PartyID, partyCount, threshold int
 	}
 }
 
+// EC returns the curve of the key the party works on
+func (params *Parameters) EC() elliptic.Curve {
+	return params.ec
+}
+
 func (params *Parameters) Parties() *PeerContext {
 	return params.parties
 }
@@ -75,8 +86,8 @@ func (params *Parameters) SafePrimeGenTimeout() time.Duration {
 // ----- //
 
 // Exported, used in `tss` client
-func NewReSharingParameters(ctx, newCtx *PeerContext, partyID *PartyID, partyCount, threshold, newPartyCount, newThreshold int) *ReSharingParameters {
-	params := NewParameters(ctx, partyID, partyCount, threshold)
+func NewReSharingParameters(ec elliptic.Curve, ctx, newCtx *PeerContext, partyID *PartyID, partyCount, threshold, newPartyCount, newThreshold int) *ReSharingParameters {
+	params := NewParameters(ec, ctx, partyID, partyCount, threshold)
 	return &ReSharingParameters{
 		Parameters:    params,
 		newParties:    newCtx,
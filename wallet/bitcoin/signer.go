@@ -0,0 +1,136 @@
+// Package bitcoin uses a threshold key as a bitcoin key: it derives the
+// P2PKH and P2WPKH addresses of the key and signs the transaction inputs
+// spending them.
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"CipherMachine/threshold"
+	tsscrypto "CipherMachine/tsslib/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// Signer signs 32 byte digests with a threshold key, the TssReactor and the
+// Node are signers.
+type Signer interface {
+	SignDigest(ctx context.Context, keyID threshold.KeyID, digest [32]byte) *threshold.SignResult
+}
+
+// PubKey returns the public key pub as a btcec key.
+func PubKey(pub *tsscrypto.ECPoint) *btcec.PublicKey {
+	return &btcec.PublicKey{Curve: btcec.S256(), X: pub.X(), Y: pub.Y()}
+}
+
+// P2PKHAddress returns the pay to pubkey hash address of the compressed
+// public key pub on the network of params.
+func P2PKHAddress(pub *tsscrypto.ECPoint, params *chaincfg.Params) (*btcutil.AddressPubKeyHash, error) {
+	return btcutil.NewAddressPubKeyHash(btcutil.Hash160(PubKey(pub).SerializeCompressed()), params)
+}
+
+// P2WPKHAddress returns the pay to witness pubkey hash address of public key
+// pub on the network of params.
+func P2WPKHAddress(pub *tsscrypto.ECPoint, params *chaincfg.Params) (*btcutil.AddressWitnessPubKeyHash, error) {
+	return btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(PubKey(pub).SerializeCompressed()), params)
+}
+
+// Prevout is the output an input spends.
+type Prevout struct {
+	PkScript []byte
+	//in satoshi, segwit inputs sign it
+	Amount int64
+}
+
+// TxSigner signs the inputs of a transaction that spend the P2PKH or P2WPKH
+// outputs of a threshold key.
+type TxSigner struct {
+	signer Signer
+	keyID  threshold.KeyID
+	pubKey *btcec.PublicKey
+}
+
+// NewTxSigner returns the signer of the key keyID with public key pub,
+// signed for by signer.
+func NewTxSigner(signer Signer, keyID threshold.KeyID, pub *tsscrypto.ECPoint) *TxSigner {
+	return &TxSigner{signer: signer, keyID: keyID, pubKey: PubKey(pub)}
+}
+
+// SignTx signs every input of tx with SIGHASH_ALL, input i spends
+// prevouts[i]. Legacy inputs are signed with the legacy sighash, segwit inputs
+// with the BIP143 one, each by its own threshold signing. It returns the
+// signed copy of tx.
+func (s *TxSigner) SignTx(ctx context.Context, tx *wire.MsgTx, prevouts []Prevout) (*wire.MsgTx, error) {
+	if len(prevouts) != len(tx.TxIn) {
+		return nil, fmt.Errorf("%d prevouts for %d inputs", len(prevouts), len(tx.TxIn))
+	}
+	signed := tx.Copy()
+	pubKeyBytes := s.pubKey.SerializeCompressed()
+	pubKeyHash := btcutil.Hash160(pubKeyBytes)
+	// the sighashes of the inputs are made over the unsigned tx
+	sigHashes := txscript.NewTxSigHashes(tx)
+	for i, prevout := range prevouts {
+		// the network does not change the hash of the address
+		class, addrs, _, err := txscript.ExtractPkScriptAddrs(prevout.PkScript, &chaincfg.MainNetParams)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %v", i, err)
+		}
+		if len(addrs) != 1 || !bytes.Equal(addrs[0].ScriptAddress(), pubKeyHash) {
+			return nil, fmt.Errorf("input %d does not spend the key", i)
+		}
+
+		var hash []byte
+		switch class {
+		case txscript.PubKeyHashTy:
+			hash, err = txscript.CalcSignatureHash(prevout.PkScript, txscript.SigHashAll, tx, i)
+		case txscript.WitnessV0PubKeyHashTy:
+			hash, err = txscript.CalcWitnessSigHash(prevout.PkScript, sigHashes, txscript.SigHashAll, tx, i, prevout.Amount)
+		default:
+			return nil, fmt.Errorf("input %d spends a %s output", i, class)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %v", i, err)
+		}
+		sig, err := s.sign(ctx, hash)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %v", i, err)
+		}
+
+		if class == txscript.PubKeyHashTy {
+			script, err := txscript.NewScriptBuilder().AddData(sig).AddData(pubKeyBytes).Script()
+			if err != nil {
+				return nil, err
+			}
+			signed.TxIn[i].SignatureScript = script
+		} else {
+			signed.TxIn[i].Witness = wire.TxWitness{sig, pubKeyBytes}
+		}
+	}
+	return signed, nil
+}
+
+// sign runs the threshold signing of the sighash and returns the DER encoded
+// signature followed by the sighash type.
+func (s *TxSigner) sign(ctx context.Context, hash []byte) ([]byte, error) {
+	var digest [32]byte
+	copy(digest[:], hash)
+	res := s.signer.SignDigest(ctx, s.keyID, digest)
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	if res.Signature == nil {
+		return nil, errors.New("no signature")
+	}
+	sig := &btcec.Signature{R: new(big.Int).SetBytes(res.Signature.R), S: new(big.Int).SetBytes(res.Signature.S)}
+	if !sig.Verify(hash, s.pubKey) {
+		return nil, errors.New("invalid signature")
+	}
+	return append(sig.Serialize(), byte(txscript.SigHashAll)), nil
+}
@@ -0,0 +1,107 @@
+package bitcoin
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"CipherMachine/threshold"
+	tsscommon "CipherMachine/tsslib/common"
+	tsscrypto "CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/tss"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// testSigner signs like the threshold signing does, with low S and R and S
+// without leading zero bytes.
+type testSigner struct {
+	key *btcec.PrivateKey
+	n   int
+}
+
+func (s *testSigner) SignDigest(ctx context.Context, keyID threshold.KeyID, digest [32]byte) *threshold.SignResult {
+	s.n++
+	sig, err := s.key.Sign(digest[:])
+	if err != nil {
+		panic(err)
+	}
+	return &threshold.SignResult{KeyID: keyID, HashAlg: threshold.HashNone, Signature: &tsscommon.SignatureData{
+		R: sig.R.Bytes(),
+		S: sig.S.Bytes(),
+		M: digest[:],
+	}}
+}
+
+func testPubKey(key *btcec.PrivateKey) *tsscrypto.ECPoint {
+	return tsscrypto.NewECPointNoCurveCheck(tss.EC(), key.PubKey().X, key.PubKey().Y)
+}
+
+func TestAddress(t *testing.T) {
+	// the key of BIP173's example address
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), big.NewInt(1).Bytes())
+	pub := testPubKey(key)
+	p2pkh, err := P2PKHAddress(pub, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	require.Equal(t, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", p2pkh.EncodeAddress())
+	p2wpkh, err := P2WPKHAddress(pub, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	require.Equal(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", p2wpkh.EncodeAddress())
+}
+
+func TestSignTx(t *testing.T) {
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+	pub := testPubKey(key)
+	p2pkh, err := P2PKHAddress(pub, &chaincfg.TestNet3Params)
+	require.NoError(t, err)
+	p2wpkh, err := P2WPKHAddress(pub, &chaincfg.TestNet3Params)
+	require.NoError(t, err)
+	p2pkhScript, err := txscript.PayToAddrScript(p2pkh)
+	require.NoError(t, err)
+	p2wpkhScript, err := txscript.PayToAddrScript(p2wpkh)
+	require.NoError(t, err)
+
+	prevouts := []Prevout{
+		{PkScript: p2pkhScript, Amount: 50000},
+		{PkScript: p2wpkhScript, Amount: 70000},
+		{PkScript: p2wpkhScript, Amount: 30000},
+	}
+	tx := wire.NewMsgTx(2)
+	for i := range prevouts {
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{byte(i + 1)}, uint32(i)), nil, nil))
+	}
+	tx.AddTxOut(wire.NewTxOut(140000, p2wpkhScript))
+
+	signer := &testSigner{key: key}
+	signed, err := NewTxSigner(signer, "key", pub).SignTx(context.Background(), tx, prevouts)
+	require.NoError(t, err)
+	require.Equal(t, len(prevouts), signer.n)
+	// the unsigned tx is left as is
+	require.Nil(t, tx.TxIn[0].SignatureScript)
+
+	sigHashes := txscript.NewTxSigHashes(signed)
+	for i, prevout := range prevouts {
+		vm, err := txscript.NewEngine(prevout.PkScript, signed, i, txscript.StandardVerifyFlags, nil, sigHashes, prevout.Amount)
+		require.NoError(t, err)
+		require.NoError(t, vm.Execute(), "input %d", i)
+	}
+
+	// a segwit input signs its amount
+	prevouts[1].Amount++
+	vm, err := txscript.NewEngine(prevouts[1].PkScript, signed, 1, txscript.StandardVerifyFlags, nil, sigHashes, prevouts[1].Amount)
+	require.NoError(t, err)
+	require.Error(t, vm.Execute())
+
+	// inputs of another key are refused
+	other, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+	_, err = NewTxSigner(&testSigner{key: other}, "other", testPubKey(other)).SignTx(context.Background(), tx, prevouts)
+	require.Error(t, err)
+	_, err = NewTxSigner(signer, "key", pub).SignTx(context.Background(), tx, prevouts[:1])
+	require.Error(t, err)
+}
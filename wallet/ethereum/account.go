@@ -0,0 +1,204 @@
+// Package ethereum uses a threshold key as an ethereum account: it derives
+// the address of the key and signs transactions and typed data with it.
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"CipherMachine/threshold"
+	tsscommon "CipherMachine/tsslib/common"
+	tsscrypto "CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/tss"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+// Signer signs 32 byte digests with a threshold key, the TssReactor and the
+// Node are signers.
+type Signer interface {
+	SignDigest(ctx context.Context, keyID threshold.KeyID, digest [32]byte) *threshold.SignResult
+}
+
+// errNotSecp256k1ECDSA is returned for a key an ethereum account cannot use.
+var errNotSecp256k1ECDSA = errors.New("an ethereum account needs a secp256k1 ECDSA key")
+
+// PubKey returns the public key of the key info describes, as the key
+// inventory lists it. Only a secp256k1 ECDSA key is an ethereum account.
+func PubKey(info *threshold.KeyInfo) (*tsscrypto.ECPoint, error) {
+	if info.KeyType != threshold.KeyTypeECDSA || info.Curve != tss.Secp256k1 {
+		return nil, errNotSecp256k1ECDSA
+	}
+	return DecompressPubKey(info.PubKey)
+}
+
+// Address returns the address of the key info describes, its Hex method
+// gives the EIP-55 checksummed form.
+func Address(info *threshold.KeyInfo) (common.Address, error) {
+	pub, err := PubKey(info)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(ecdsa.PublicKey{Curve: crypto.S256(), X: pub.X(), Y: pub.Y()}), nil
+}
+
+// ChecksumAddress returns the EIP-55 checksummed address of the key info
+// describes.
+func ChecksumAddress(info *threshold.KeyInfo) (string, error) {
+	address, err := Address(info)
+	if err != nil {
+		return "", err
+	}
+	return address.Hex(), nil
+}
+
+// DecompressPubKey returns the public key of its compressed form, as the key
+// inventory lists it.
+func DecompressPubKey(bz []byte) (*tsscrypto.ECPoint, error) {
+	pub, err := crypto.DecompressPubkey(bz)
+	if err != nil {
+		return nil, err
+	}
+	return tsscrypto.NewECPoint(tss.S256(), pub.X, pub.Y)
+}
+
+// Signature returns the 65 byte r||s||v form of sig, v is the recovery id 0
+// or 1.
+func Signature(sig *tsscommon.SignatureData) ([]byte, error) {
+	if sig == nil || len(sig.R) == 0 || len(sig.R) > 32 || len(sig.S) == 0 || len(sig.S) > 32 {
+		return nil, errors.New("invalid signature")
+	}
+	if len(sig.SignatureRecovery) == 0 || sig.SignatureRecovery[0] > 1 {
+		return nil, errors.New("signature has no recovery id")
+	}
+	out := make([]byte, 65)
+	copy(out[32-len(sig.R):32], sig.R)
+	copy(out[64-len(sig.S):64], sig.S)
+	out[64] = sig.SignatureRecovery[0]
+	return out, nil
+}
+
+// VerifySignature checks that sig, in r||s||v form with v 0, 1, 27 or 28, is
+// a signature of hash by address.
+func VerifySignature(address common.Address, hash common.Hash, sig []byte) error {
+	if len(sig) != 65 {
+		return fmt.Errorf("signature is %d bytes, want 65", len(sig))
+	}
+	rsv := append([]byte(nil), sig...)
+	if rsv[64] >= 27 {
+		rsv[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash[:], rsv)
+	if err != nil {
+		return err
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != address {
+		return fmt.Errorf("signature is by %s, not %s", signer.Hex(), address.Hex())
+	}
+	return nil
+}
+
+// Account is the ethereum account of a threshold key.
+type Account struct {
+	signer  Signer
+	keyID   threshold.KeyID
+	address common.Address
+}
+
+// NewAccount returns the account of the key info describes, signed for by
+// signer. The key must be a secp256k1 ECDSA key.
+func NewAccount(signer Signer, info *threshold.KeyInfo) (*Account, error) {
+	address, err := Address(info)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{signer: signer, keyID: info.KeyID, address: address}, nil
+}
+
+// Address returns the address of the account.
+func (a *Account) Address() common.Address {
+	return a.address
+}
+
+// SignHash signs hash and returns the r||s||v signature, v is the recovery id
+// 0 or 1. The signature is checked to recover to the account address.
+func (a *Account) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	res := a.signer.SignDigest(ctx, a.keyID, hash)
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	sig, err := Signature(res.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifySignature(a.address, hash, sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignTx signs a legacy transaction, replay protected by EIP-155 unless
+// chainID is nil.
+func (a *Account) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	var signer types.Signer = types.HomesteadSigner{}
+	if chainID != nil {
+		signer = types.NewEIP155Signer(chainID)
+	}
+	sig, err := a.SignHash(ctx, signer.Hash(tx))
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// SignDynamicFeeTx signs an EIP-1559 transaction and returns the signature
+// and the signed transaction encoding.
+func (a *Account) SignDynamicFeeTx(ctx context.Context, tx *DynamicFeeTx) ([]byte, []byte, error) {
+	hash, err := tx.SigHash()
+	if err != nil {
+		return nil, nil, err
+	}
+	sig, err := a.SignHash(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	raw, err := tx.EncodeSigned(sig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, raw, nil
+}
+
+// TypedDataHash returns the hash of EIP-712 typed data that is signed,
+// keccak256(0x19 0x01 || hashStruct(domain) || hashStruct(message)).
+func TypedDataHash(data *core.TypedData) (common.Hash, error) {
+	domainSeparator, err := data.HashStruct("EIP712Domain", data.Domain.Map())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	msgHash, err := data.HashStruct(data.PrimaryType, data.Message)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, msgHash), nil
+}
+
+// SignTypedData signs EIP-712 typed data, v of the signature is 27 or 28 as
+// eth_signTypedData returns it.
+func (a *Account) SignTypedData(ctx context.Context, data *core.TypedData) ([]byte, error) {
+	hash, err := TypedDataHash(data)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := a.SignHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
@@ -0,0 +1,281 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"CipherMachine/threshold"
+	tsscommon "CipherMachine/tsslib/common"
+	tsscrypto "CipherMachine/tsslib/crypto"
+	"CipherMachine/tsslib/tss"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/signer/core"
+	"github.com/stretchr/testify/require"
+)
+
+// testSigner signs like the threshold signing does, with R and S without
+// leading zero bytes and the recovery id apart.
+type testSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *testSigner) SignDigest(ctx context.Context, keyID threshold.KeyID, digest [32]byte) *threshold.SignResult {
+	sig, err := crypto.Sign(digest[:], s.key)
+	if err != nil {
+		panic(err)
+	}
+	return &threshold.SignResult{KeyID: keyID, HashAlg: threshold.HashNone, Signature: &tsscommon.SignatureData{
+		R:                 new(big.Int).SetBytes(sig[:32]).Bytes(),
+		S:                 new(big.Int).SetBytes(sig[32:64]).Bytes(),
+		SignatureRecovery: []byte{sig[64]},
+		M:                 digest[:],
+	}}
+}
+
+// testKeyInfo returns the info of a secp256k1 ECDSA key with public key pub,
+// as the key inventory lists it.
+func testKeyInfo(pub *ecdsa.PublicKey) *threshold.KeyInfo {
+	return &threshold.KeyInfo{
+		KeyID:   "key",
+		KeyType: threshold.KeyTypeECDSA,
+		Curve:   tss.Secp256k1,
+		PubKey:  crypto.CompressPubkey(pub),
+	}
+}
+
+func newTestAccount(t *testing.T, hexKey string) *Account {
+	key, err := crypto.HexToECDSA(hexKey)
+	require.NoError(t, err)
+	a, err := NewAccount(&testSigner{key}, testKeyInfo(&key.PublicKey))
+	require.NoError(t, err)
+	return a
+}
+
+func TestAddress(t *testing.T) {
+	key, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	require.NoError(t, err)
+	info := testKeyInfo(&key.PublicKey)
+	address, err := ChecksumAddress(info)
+	require.NoError(t, err)
+	require.Equal(t, "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23", address)
+	pub, err := PubKey(info)
+	require.NoError(t, err)
+	require.True(t, pub.Equals(tsscrypto.NewECPointNoCurveCheck(tss.S256(), key.X, key.Y)))
+}
+
+// only a secp256k1 ECDSA key is an ethereum account
+func TestAddressKeyType(t *testing.T) {
+	key, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	require.NoError(t, err)
+	for _, kt := range []threshold.KeyType{threshold.KeyTypeEdDSA, threshold.KeyTypeSchnorr, ""} {
+		info := testKeyInfo(&key.PublicKey)
+		info.KeyType = kt
+		_, err := Address(info)
+		require.Error(t, err, kt)
+		_, err = NewAccount(&testSigner{key}, info)
+		require.Error(t, err, kt)
+	}
+	info := testKeyInfo(&key.PublicKey)
+	info.Curve = tss.P256
+	_, err = Address(info)
+	require.Error(t, err)
+}
+
+func TestSignTx(t *testing.T) {
+	a := newTestAccount(t, "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	ctx := context.Background()
+	to := common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")
+	tx := types.NewTransaction(7, to, big.NewInt(1e18), 21000, big.NewInt(2e9), nil)
+
+	// legacy
+	signed, err := a.SignTx(ctx, tx, nil)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.HomesteadSigner{}, signed)
+	require.NoError(t, err)
+	require.Equal(t, a.Address(), sender)
+
+	// EIP-155
+	chainID := big.NewInt(1)
+	signed, err = a.SignTx(ctx, tx, chainID)
+	require.NoError(t, err)
+	require.True(t, signed.Protected())
+	sender, err = types.Sender(types.NewEIP155Signer(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, a.Address(), sender)
+	v, _, _ := signed.RawSignatureValues()
+	require.True(t, v.Cmp(big.NewInt(37)) == 0 || v.Cmp(big.NewInt(38)) == 0)
+}
+
+func TestSignDynamicFeeTx(t *testing.T) {
+	a := newTestAccount(t, "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	to := common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")
+	tx := &DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     3,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(100e9),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1e18),
+		AccessList: []AccessTuple{
+			{Address: to, StorageKeys: []common.Hash{{1}}},
+		},
+	}
+	sig, raw, err := a.SignDynamicFeeTx(context.Background(), tx)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+	hash, err := tx.SigHash()
+	require.NoError(t, err)
+	require.NoError(t, VerifySignature(a.Address(), hash, sig))
+
+	// the signed encoding carries the fields and the signature
+	require.Equal(t, byte(DynamicFeeTxType), raw[0])
+	var decoded struct {
+		ChainID, Nonce, GasTipCap, GasFeeCap, Gas *big.Int
+		To                                        []byte
+		Value                                     *big.Int
+		Data                                      []byte
+		AccessList                                []AccessTuple
+		V, R, S                                   *big.Int
+	}
+	require.NoError(t, rlp.DecodeBytes(raw[1:], &decoded))
+	require.Equal(t, to.Bytes(), decoded.To)
+	require.Equal(t, tx.AccessList, decoded.AccessList)
+	require.Equal(t, uint64(sig[64]), decoded.V.Uint64())
+	require.Equal(t, new(big.Int).SetBytes(sig[:32]), decoded.R)
+	require.Equal(t, new(big.Int).SetBytes(sig[32:64]), decoded.S)
+
+	// contract creation
+	tx.To = nil
+	_, _, err = a.SignDynamicFeeTx(context.Background(), tx)
+	require.NoError(t, err)
+	tx.GasFeeCap = nil
+	_, _, err = a.SignDynamicFeeTx(context.Background(), tx)
+	require.Error(t, err)
+}
+
+// the vectors are signed by types.NewLondonSigner of go-ethereum v1.17.7 with
+// the same key, its signatures are deterministic like the ones of testSigner
+func TestDynamicFeeTxVectors(t *testing.T) {
+	a := newTestAccount(t, "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	to := common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")
+	vectors := []struct {
+		tx      *DynamicFeeTx
+		sigHash string
+		raw     string
+		txHash  string
+	}{{
+		tx: &DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     3,
+			GasTipCap: big.NewInt(1e9),
+			GasFeeCap: big.NewInt(100e9),
+			Gas:       21000,
+			To:        &to,
+			Value:     big.NewInt(1e18),
+			AccessList: []AccessTuple{
+				{Address: to, StorageKeys: []common.Hash{{1}}},
+			},
+		},
+		sigHash: "09def267377b15c9a2caaa2a1ffff7075acdeac977f5b11aefb7b2a625da274b",
+		raw:     "02f8ac0103843b9aca0085174876e80082520894bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb880de0b6b3a764000080f838f794bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbe1a0010000000000000000000000000000000000000000000000000000000000000080a089b6e507971d25bb6d65d8c3193665fc59ac470d9afecdb71977c155e28b78baa06b9587e74b3c20d22d1338f6b0e0b3901dd5025393d76ac1c4004b1ef0e8f60d",
+		txHash:  "0xbceffe988426273f6a8ccc86c25c02e80d4b7c6fe9eb71204b14bb7d67198d08",
+	}, {
+		// contract creation without an access list
+		tx: &DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     0,
+			GasTipCap: big.NewInt(2e9),
+			GasFeeCap: big.NewInt(30e9),
+			Gas:       100000,
+			Value:     big.NewInt(0),
+			Data:      common.FromHex("0x6080604052"),
+		},
+		sigHash: "d02a74e73bb45168d057d40eca8ff0db9741fbf99433cd8f4db7383320db5797",
+		raw:     "02f85d018084773594008506fc23ac00830186a08080856080604052c001a0bbedd585a221001790467045c54c538eb3853340d84410a353310dcef901a952a04423e7819abc9803af7f1e0e60db94414da4b20e78518ec0820fae9f0d72c40f",
+		txHash:  "0x0fefaacc51658222e5b0546026d873fe6e5d721323189d3beb433f20d25430db",
+	}}
+	for _, v := range vectors {
+		hash, err := v.tx.SigHash()
+		require.NoError(t, err)
+		require.Equal(t, v.sigHash, hex.EncodeToString(hash[:]))
+		_, raw, err := a.SignDynamicFeeTx(context.Background(), v.tx)
+		require.NoError(t, err)
+		require.Equal(t, v.raw, hex.EncodeToString(raw))
+		// the hash of a typed transaction is the hash of its signed encoding
+		require.Equal(t, v.txHash, crypto.Keccak256Hash(raw).Hex())
+	}
+}
+
+// the example of EIP-712
+const mailTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": "1",
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func TestSignTypedData(t *testing.T) {
+	var td core.TypedData
+	require.NoError(t, json.Unmarshal([]byte(mailTypedData), &td))
+	hash, err := TypedDataHash(&td)
+	require.NoError(t, err)
+	require.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash[:]))
+
+	// signed by the key of cow
+	a := newTestAccount(t, hex.EncodeToString(crypto.Keccak256([]byte("cow"))))
+	require.Equal(t, "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", a.Address().Hex())
+	sig, err := a.SignTypedData(context.Background(), &td)
+	require.NoError(t, err)
+	require.Equal(t, "4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+"1c", hex.EncodeToString(sig))
+	require.NoError(t, VerifySignature(a.Address(), hash, sig))
+	require.Error(t, VerifySignature(common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"), hash, sig))
+
+	delete(td.Message, "contents")
+	_, err = TypedDataHash(&td)
+	require.Error(t, err)
+}
+
+func TestSignature(t *testing.T) {
+	sig, err := Signature(&tsscommon.SignatureData{R: []byte{1}, S: []byte{2}, SignatureRecovery: []byte{1}})
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+	require.Equal(t, byte(1), sig[31])
+	require.Equal(t, byte(2), sig[63])
+	require.Equal(t, byte(1), sig[64])
+	_, err = Signature(&tsscommon.SignatureData{R: []byte{1}, S: []byte{2}})
+	require.Error(t, err)
+}
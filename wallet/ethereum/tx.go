@@ -0,0 +1,85 @@
+package ethereum
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// DynamicFeeTxType is the EIP-2718 type of an EIP-1559 transaction.
+const DynamicFeeTxType = 0x02
+
+// AccessTuple is an EIP-2930 access list entry.
+type AccessTuple struct {
+	Address     common.Address
+	StorageKeys []common.Hash
+}
+
+// DynamicFeeTx is an EIP-1559 transaction. To is nil for a contract creation.
+type DynamicFeeTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Gas        uint64
+	To         *common.Address
+	Value      *big.Int
+	Data       []byte
+	AccessList []AccessTuple
+}
+
+// fields returns the rlp list of the unsigned transaction.
+func (tx *DynamicFeeTx) fields() ([]interface{}, error) {
+	if tx.ChainID == nil || tx.GasTipCap == nil || tx.GasFeeCap == nil {
+		return nil, errors.New("dynamic fee tx needs the chain id, tip cap and fee cap")
+	}
+	to := []byte{}
+	if tx.To != nil {
+		to = tx.To.Bytes()
+	}
+	value := tx.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	accessList := tx.AccessList
+	if accessList == nil {
+		accessList = []AccessTuple{}
+	}
+	return []interface{}{tx.ChainID, tx.Nonce, tx.GasTipCap, tx.GasFeeCap, tx.Gas, to, value, tx.Data, accessList}, nil
+}
+
+// SigHash returns the hash the sender signs,
+// keccak256(0x02 || rlp([chain_id, nonce, ..., access_list])).
+func (tx *DynamicFeeTx) SigHash() (common.Hash, error) {
+	fields, err := tx.fields()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	bz, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash([]byte{DynamicFeeTxType}, bz), nil
+}
+
+// EncodeSigned returns the signed transaction as it is sent to the network,
+// 0x02 || rlp([chain_id, ..., access_list, y_parity, r, s]). sig is in r||s||v
+// form with v 0 or 1.
+func (tx *DynamicFeeTx) EncodeSigned(sig []byte) ([]byte, error) {
+	if len(sig) != 65 || sig[64] > 1 {
+		return nil, errors.New("invalid signature")
+	}
+	fields, err := tx.fields()
+	if err != nil {
+		return nil, err
+	}
+	fields = append(fields, uint64(sig[64]), new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64]))
+	bz, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{DynamicFeeTxType}, bz...), nil
+}